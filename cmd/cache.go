@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/tahcohcat/ecolint/internal/scan"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "🗄️  Manage the project scan cache",
+	Long: `🗄️  Manage the project scan cache
+
+ecolint scan and ecolint lint --auto-discover keep the results of each
+scanned file in .ecolint-cache/, keyed by the file's content, so later
+runs only scan files that changed. Use --no-cache to bypass it.`,
+}
+
+var cacheCleanCmd = &cobra.Command{
+	Use:   "clean [path]",
+	Short: "🧹 Remove the scan cache",
+	Long: `🧹 Remove the scan cache
+
+Deletes the .ecolint-cache directory of a project so the next scan
+starts from scratch.
+
+Examples:
+  ecolint cache clean             # clean the cache of the current directory
+  ecolint cache clean ./services  # clean the cache of another project`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCacheClean,
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheCleanCmd)
+}
+
+func runCacheClean(cmd *cobra.Command, args []string) error {
+	root := "."
+	if len(args) > 0 {
+		root = args[0]
+	}
+
+	dir := filepath.Join(root, scan.CacheDir)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		fmt.Printf("✨ No scan cache in %s\n", root)
+		return nil
+	}
+
+	if err := scan.CleanCache(root); err != nil {
+		return fmt.Errorf("failed to remove scan cache: %w", err)
+	}
+	fmt.Printf("🧹 Removed %s\n", dir)
+	return nil
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tahcohcat/ecolint/domain/schema"
+	"github.com/tahcohcat/ecolint/internal/output"
+	"github.com/tahcohcat/ecolint/internal/scan"
+)
+
+var docsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "📚 Generate Markdown documentation of environment variables",
+	Long: `📚 Generate Markdown documentation of environment variables
+
+Writes a table of every variable in the environment contract (see
+'ecolint schema'): its type, whether it is required, its default, its
+description and the files that read it.
+
+Use --check in CI to fail when the committed file is out of date.
+
+Examples:
+  ecolint docs                    # write ENVIRONMENT.md
+  ecolint docs -o docs/env.md     # write somewhere else
+  ecolint docs -o -               # print to stdout
+  ecolint docs --check            # exit 1 if ENVIRONMENT.md is stale`,
+	Args: cobra.NoArgs,
+	RunE: runDocs,
+}
+
+var (
+	docsOutputFlag string
+	docsCheckFlag  bool
+)
+
+// docsMaxFiles is how many files are listed per variable
+const docsMaxFiles = 3
+
+func init() {
+	rootCmd.AddCommand(docsCmd)
+
+	docsCmd.Flags().StringVarP(&docsOutputFlag, "output", "o", "ENVIRONMENT.md", "file to write, or - for stdout")
+	docsCmd.Flags().BoolVar(&docsCheckFlag, "check", false, "compare with the existing file instead of writing it")
+	addContractFlags(docsCmd)
+}
+
+func runDocs(cmd *cobra.Command, args []string) error {
+	contract, result, err := loadContract(cmd)
+	if err != nil {
+		return err
+	}
+	content := renderDocs(contract, result, scanPathFlag)
+
+	if docsCheckFlag {
+		existing, err := os.ReadFile(docsOutputFlag)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if !bytes.Equal(existing, content) {
+			fmt.Printf("❌ %s is out of date\n", docsOutputFlag)
+			fmt.Println("💡 Run: ecolint docs -o " + docsOutputFlag)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ %s is up to date\n", docsOutputFlag)
+		return nil
+	}
+
+	if docsOutputFlag == "-" {
+		_, err = os.Stdout.Write(content)
+		return err
+	}
+	if err := os.WriteFile(docsOutputFlag, content, 0644); err != nil {
+		return fmt.Errorf("failed to write docs: %w", err)
+	}
+	fmt.Printf("📚 Documented %s in %s\n", output.Plural(len(contract), "variable"), docsOutputFlag)
+	return nil
+}
+
+// renderDocs formats the contract as a Markdown table. Files are listed
+// without line numbers so unrelated edits don't make the docs stale.
+func renderDocs(contract schema.Schema, result *scan.ScanResult, root string) []byte {
+	var b bytes.Buffer
+	b.WriteString("# Environment Variables\n\n")
+	b.WriteString("<!-- Generated by `ecolint docs`. Edit .ecolint.yaml and run it again instead of editing this file. -->\n\n")
+
+	if len(contract) == 0 {
+		b.WriteString("No environment variables are declared or discovered.\n")
+		return b.Bytes()
+	}
+
+	required := 0
+	for _, f := range contract {
+		if f.Required {
+			required++
+		}
+	}
+	fmt.Fprintf(&b, "%s, %d required.\n\n", output.Plural(len(contract), "variable"), required)
+
+	b.WriteString("| Variable | Type | Required | Default | Description | Used in |\n")
+	b.WriteString("|----------|------|----------|---------|-------------|---------|\n")
+	for _, f := range contract {
+		requiredCell := "no"
+		if f.Required {
+			requiredCell = "yes"
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s | %s |\n",
+			f.Name,
+			markdownCell(describeField(f)),
+			requiredCell,
+			codeCell(f.Default),
+			markdownCell(f.Description),
+			usedIn(result, f.Name, root),
+		)
+	}
+	return b.Bytes()
+}
+
+// describeField formats a field's type with its constraints, e.g. "int (1–64)"
+func describeField(f schema.Field) string {
+	var constraints []string
+	switch {
+	case f.Type == schema.TypeEnum:
+		constraints = append(constraints, strings.Join(f.Values, ", "))
+	case f.Type == schema.TypeURL && len(f.Schemes) > 0:
+		constraints = append(constraints, strings.Join(f.Schemes, ", "))
+	}
+
+	unit := ""
+	switch f.Type {
+	case schema.TypeInt, schema.TypeFloat, schema.TypePort, schema.TypeDuration:
+	default:
+		unit = " chars"
+	}
+	switch {
+	case f.Min != "" && f.Max != "":
+		constraints = append(constraints, f.Min+"–"+f.Max+unit)
+	case f.Min != "":
+		constraints = append(constraints, "≥ "+f.Min+unit)
+	case f.Max != "":
+		constraints = append(constraints, "≤ "+f.Max+unit)
+	}
+	if f.Pattern != "" {
+		constraints = append(constraints, "`"+f.Pattern+"`")
+	}
+
+	if len(constraints) == 0 {
+		return string(f.Type)
+	}
+	return fmt.Sprintf("%s (%s)", f.Type, strings.Join(constraints, "; "))
+}
+
+// usedIn lists the files that read a variable, relative to root
+func usedIn(result *scan.ScanResult, name, root string) string {
+	if result == nil {
+		return ""
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, usage := range result.Variables[name] {
+		path, err := filepath.Rel(root, usage.File)
+		if err != nil {
+			path = usage.File
+		}
+		path = filepath.ToSlash(path)
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+
+	more := ""
+	if len(files) > docsMaxFiles {
+		more = fmt.Sprintf(" and %d more", len(files)-docsMaxFiles)
+		files = files[:docsMaxFiles]
+	}
+	for i, file := range files {
+		files[i] = codeCell(file)
+	}
+	return strings.Join(files, ", ") + more
+}
+
+func codeCell(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + markdownCell(s) + "`"
+}
+
+// markdownCell keeps text on one line and inside its table cell
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -27,6 +27,11 @@ This command checks your .env files for:
 • Empty values
 • Security issues (potential secrets)
 • Naming conventions
+• Values that don't match the schema (types, enums, ranges, patterns)
+• Keys missing from some variants, e.g. .env.staging vs .env.production (rules.consistency)
+• Keys missing from .env.example, or listed there but not set (rules.example)
+• Variables missing from, or not set by, deployment manifests (rules.deployment)
+• Variables no source file reads (rules.unused)
 
 Auto-Discovery Mode:
 When --auto-discover is used, ecolint will scan your project files to
@@ -39,13 +44,20 @@ Examples:
   ecolint lint --recursive .          # recursively find and lint all .env files
   ecolint lint --auto-discover        # auto-discover required variables
   ecolint lint --auto-discover --scan-path ./src  # scan specific directory
-  ecolint lint --format json          # output in JSON format`,
+  ecolint lint --format json          # output in JSON format
+  ecolint lint --redact partial       # partially mask secret values in output
+  ecolint lint --format html > report.html  # standalone HTML report
+  ecolint lint --format markdown --baseline main.json >> "$GITHUB_STEP_SUMMARY"
+  ecolint lint --format template --template report.tmpl  # render a custom report`,
 	RunE: runLint,
 }
 
 var (
 	recursiveFlag     bool
 	formatFlag        string
+	redactFlag        string
+	templateFlag      string
+	baselineFlag      string
 	quietFlag         bool
 	configFlag        string
 	autoDiscoverFlag  bool
@@ -58,13 +70,17 @@ func init() {
 	rootCmd.AddCommand(lintCmd)
 
 	lintCmd.Flags().BoolVarP(&recursiveFlag, "recursive", "r", false, "recursively search for .env files")
-	lintCmd.Flags().StringVarP(&formatFlag, "format", "f", "", "output format (pretty, json, github)")
+	lintCmd.Flags().StringVarP(&formatFlag, "format", "f", "", "output format (pretty, json, github, markdown, html, template)")
+	lintCmd.Flags().StringVar(&redactFlag, "redact", "", "mask secret values in output (none, partial, full); defaults to full for every format except pretty")
+	lintCmd.Flags().StringVar(&baselineFlag, "baseline", "", "previous --format json report to compare against (markdown)")
+	lintCmd.Flags().StringVar(&templateFlag, "template", "", "path to a Go text/template file for --format template")
 	lintCmd.Flags().BoolVarP(&quietFlag, "quiet", "q", false, "suppress output when no issues found")
 	lintCmd.Flags().StringVarP(&configFlag, "config", "c", "", "path to configuration file")
 	lintCmd.Flags().BoolVar(&autoDiscoverFlag, "auto-discover", false, "automatically discover required variables by scanning project")
 	lintCmd.Flags().StringVar(&scanPathFlag, "scan-path", ".", "path to scan for auto-discovery (default: current directory)")
 	lintCmd.Flags().Float64Var(&minConfidenceFlag, "min-confidence", 0.7, "minimum confidence for auto-discovered variables (0.0-1.0)")
 	lintCmd.Flags().IntVar(&minUsagesFlag, "min-usages", 1, "minimum usages for auto-discovered variables")
+	lintCmd.Flags().BoolVar(&noCacheFlag, "no-cache", false, "scan every file instead of reusing results from "+scan.CacheDir)
 }
 
 func runLint(cmd *cobra.Command, args []string) error {
@@ -75,13 +91,69 @@ func runLint(cmd *cobra.Command, args []string) error {
 	if formatFlag != "" {
 		cfg.Output.Format = formatFlag
 	}
+	if redactFlag != "" {
+		cfg.Output.Redact = redactFlag
+	}
+	if templateFlag != "" {
+		cfg.Output.Template = templateFlag
+	}
 
-	// Auto-discover required variables if requested
-	if autoDiscoverFlag {
-		discoveredVars, err := autoDiscoverRequiredVars()
+	// Validate the schema and redaction mode before doing any work
+	contract, err := configuredSchema(cfg)
+	if err != nil {
+		return err
+	}
+	cfg.Schema = contract
+	security, err := securityOptions(cfg.Security)
+	if err != nil {
+		return err
+	}
+	cfg.RequiredVars = mergeLists(cfg.RequiredVars, cfg.Schema.Required())
+
+	formatter := output.NewFormatter(cfg.Output.Format, quietFlag).
+		WithTemplate(cfg.Output.Template).
+		WithSecurityOptions(security)
+	if cfg.Output.Redact != "" {
+		mode, err := output.ParseRedactMode(cfg.Output.Redact)
 		if err != nil {
-			return fmt.Errorf("auto-discovery failed: %w", err)
+			return err
+		}
+		formatter.WithRedaction(mode)
+	}
+	if baselineFlag != "" {
+		baseline, err := output.LoadBaseline(baselineFlag)
+		if err != nil {
+			return err
 		}
+		formatter.WithBaseline(baseline)
+	}
+
+	// Flags take precedence over the config file
+	if !cmd.Flags().Changed("min-confidence") {
+		minConfidenceFlag = cfg.Scan.MinConfidence
+	}
+	if !cmd.Flags().Changed("min-usages") {
+		minUsagesFlag = cfg.Scan.MinUsages
+	}
+
+	// Scan the project once for auto-discovery, deployment manifests and unused variables
+	var scanResult *scan.ScanResult
+	if autoDiscoverFlag || cfg.Rules.Deployment || cfg.Rules.Unused {
+		scanner := newProjectScanner(cfg, nil, nil, nil)
+		if !noCacheFlag {
+			scanner = scanner.WithCache(scan.OpenCache(scan.DefaultCachePath(scanPathFlag)))
+		}
+		result, err := scanner.ScanProjectContext(cmd.Context(), scanPathFlag)
+		if err != nil {
+			return fmt.Errorf("project scan failed: %w", err)
+		}
+		scanResult = result
+	}
+
+	// Auto-discover required variables if requested
+	if autoDiscoverFlag {
+		// Get required variables based on confidence and usage thresholds
+		discoveredVars := scanResult.GetRequiredVariables(minConfidenceFlag, minUsagesFlag)
 
 		if !quietFlag && len(discoveredVars) > 0 {
 			fmt.Printf("🔍 Auto-discovered %d required variables from project scan\n", len(discoveredVars))
@@ -115,11 +187,34 @@ func runLint(cmd *cobra.Command, args []string) error {
 		linter.WithRule(rules.Missing(cfg.RequiredVars))
 	}
 	if cfg.Rules.Security {
-		linter.WithRule(rules.Security)
+		// Files named on the command line are a partial lint, where entries
+		// for the other files would look unused
+		if len(security.Allow) > 0 && !namesFiles(args) {
+			security.AllowUsage = rules.NewAllowUsage()
+			linter.WithMultiFileRule(rules.UnusedAllowEntries(security.Allow, security.AllowUsage, cfg.Path))
+		}
+		linter.WithRule(rules.SecurityRule(security))
 	}
 	if cfg.Rules.Convention {
 		linter.WithRule(rules.Convention)
 	}
+	if cfg.Rules.Deployment {
+		linter.WithRule(rules.Deployment(declaredVars(scanResult), scanResult.EnvFiles))
+	}
+	if cfg.Rules.Schema && len(cfg.Schema) > 0 {
+		linter.WithRule(rules.Schema(cfg.Schema))
+	}
+	allowDiffer := append(append([]string{}, rules.DefaultAllowDiffer...), cfg.Consistency.AllowDiffer...)
+	if cfg.Rules.Consistency {
+		linter.WithMultiFileRule(rules.Consistency(cfg.Example.Template, allowDiffer))
+	}
+	if cfg.Rules.Example {
+		linter.WithRule(rules.Example(cfg.Example.Template, allowDiffer))
+	}
+	if cfg.Rules.Unused {
+		allow := append(append([]string{}, rules.DefaultUnusedAllow...), cfg.AllowUnused...)
+		linter.WithRule(rules.Unused(references(scanResult), minConfidenceFlag, allow))
+	}
 
 	// Run linting
 	issues, err := linter.Lint(files)
@@ -128,8 +223,9 @@ func runLint(cmd *cobra.Command, args []string) error {
 	}
 
 	// Format and print results
-	formatter := output.NewFormatter(cfg.Output.Format, quietFlag)
-	formatter.PrintResults(issues, files)
+	if err := formatter.PrintResults(issues, files); err != nil {
+		return err
+	}
 
 	// Exit with error code if issues found
 	if len(issues) > 0 {
@@ -139,20 +235,65 @@ func runLint(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func autoDiscoverRequiredVars() ([]string, error) {
-	// Create scanner
-	scanner := scan.NewProjectScanner()
-
-	// Perform scan
-	result, err := scanner.ScanProject(scanPathFlag)
+// securityOptions converts the security section of the config
+func securityOptions(cfg config.Security) (rules.SecurityOptions, error) {
+	confidence, err := rules.ParseConfidence(cfg.MinConfidence)
 	if err != nil {
-		return nil, err
+		return rules.SecurityOptions{}, fmt.Errorf("invalid configuration: security.min_confidence: %w", err)
 	}
+	detectors, err := rules.MergeDetectors(rules.DefaultDetectors, cfg.Detectors)
+	if err != nil {
+		return rules.SecurityOptions{}, fmt.Errorf("invalid configuration: security.detectors: %w", err)
+	}
+	allow, err := rules.CompileAllowlist(cfg.Allow)
+	if err != nil {
+		return rules.SecurityOptions{}, fmt.Errorf("invalid configuration: security.allow: %w", err)
+	}
+	return rules.SecurityOptions{
+		MinLength:           cfg.MinLength,
+		HexEntropy:          cfg.Entropy.Hex,
+		AlphanumericEntropy: cfg.Entropy.Alphanumeric,
+		Base64Entropy:       cfg.Entropy.Base64,
+		MinConfidence:       confidence,
+		Detectors:           detectors,
+		Placeholders:        append(append([]string{}, rules.DefaultPlaceholders...), cfg.Placeholders...),
+		Allow:               allow,
+	}, nil
+}
 
-	// Get required variables based on confidence and usage thresholds
-	required := result.GetRequiredVariables(minConfidenceFlag, minUsagesFlag)
+// declaredVars lists the variables deployment manifests set, with where
+func declaredVars(result *scan.ScanResult) []rules.DeclaredVar {
+	var declared []rules.DeclaredVar
+	for name, usages := range result.DeclaredVariables() {
+		for _, usage := range usages {
+			path, err := filepath.Rel(scanPathFlag, usage.File)
+			if err != nil {
+				path = usage.File
+			}
+			declared = append(declared, rules.DeclaredVar{
+				Name:   name,
+				Source: fmt.Sprintf("%s:%d (%s)", path, usage.Line, usage.Manifest),
+			})
+		}
+	}
+	return declared
+}
 
-	return required, nil
+// references summarizes how the scan saw each variable being read
+func references(result *scan.ScanResult) map[string]rules.Reference {
+	refs := make(map[string]rules.Reference)
+	for name, usages := range result.Variables {
+		var ref rules.Reference
+		for _, usage := range usages {
+			if usage.Manifest != "" {
+				ref.Declared = true
+			} else if usage.Confidence > ref.Confidence {
+				ref.Confidence = usage.Confidence
+			}
+		}
+		refs[name] = ref
+	}
+	return refs
 }
 
 func mergeLists(existing, discovered []string) []string {
@@ -179,6 +320,16 @@ func mergeLists(existing, discovered []string) []string {
 	return merged
 }
 
+// namesFiles reports whether any argument is a file rather than a directory
+func namesFiles(args []string) bool {
+	for _, arg := range args {
+		if info, err := os.Stat(arg); err != nil || !info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
 func getFilesToLint(args []string, recursive bool) ([]string, error) {
 	var files []string
 
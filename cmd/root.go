@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
 
 	"github.com/spf13/cobra"
 )
@@ -24,7 +27,10 @@ Examples:
 }
 
 // Execute runs the root command.
-// This is what `main.go` calls.
+// This is what `main.go` calls. Ctrl-C cancels the command's context, which
+// stops long-running work such as project scans.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return rootCmd.ExecuteContext(ctx)
 }
@@ -0,0 +1,461 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tahcohcat/ecolint/internal/config"
+	"github.com/tahcohcat/ecolint/internal/output"
+	"github.com/tahcohcat/ecolint/internal/scan"
+)
+
+var scanCmd = &cobra.Command{
+	Use:   "scan [path]",
+	Short: "🔍 Discover environment variables used in your project",
+	Long: `🔍 Discover environment variables used in your project
+
+This command scans your project files to automatically discover which
+environment variables are being used. This helps identify what variables
+should be defined in your .env files without manually specifying them.
+
+The scanner looks for common patterns across multiple languages:
+• Go: os.Getenv/os.LookupEnv, constants and helper functions (parsed, not matched)
+• JavaScript/Node.js: process.env.VAR_NAME
+• Python: os.environ["VAR_NAME"] or os.getenv("VAR_NAME")
+• Shell: $VAR_NAME or ${VAR_NAME}
+• Docker: ENV VAR_NAME
+• And many more...
+
+Examples:
+  ecolint scan                    # scan current directory
+  ecolint scan ./src              # scan specific directory
+  ecolint scan --min-confidence 0.8  # only show high-confidence matches
+  ecolint scan --show-usages      # show where each variable is used
+  ecolint scan --output json      # output results in JSON format
+  ecolint scan -j 16 --timeout 2m # scan with 16 workers, give up after 2 minutes
+  ecolint scan --no-cache         # rescan every file, ignoring .ecolint-cache
+  ecolint scan --generate-config  # generate .ecolint.yaml with discovered vars`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runScan,
+}
+
+var (
+	scanMinConfidence  float64
+	scanMinUsages      int
+	scanOutput         string
+	scanGenerateConfig bool
+	scanExcludePaths   []string
+	scanIncludeExts    []string
+	scanIncludes       []string
+	scanShowUsages     bool
+	scanConcurrency    int
+	scanTimeout        time.Duration
+	noCacheFlag        bool
+)
+
+func init() {
+	rootCmd.AddCommand(scanCmd)
+
+	scanCmd.Flags().Float64Var(&scanMinConfidence, "min-confidence", 0.7, "minimum confidence score for variables (0.0-1.0)")
+	scanCmd.Flags().IntVar(&scanMinUsages, "min-usages", 1, "minimum number of usages to consider a variable required")
+	scanCmd.Flags().StringVar(&scanOutput, "output", "pretty", "output format (pretty, json, list)")
+	scanCmd.Flags().BoolVar(&scanGenerateConfig, "generate-config", false, "generate .ecolint.yaml with discovered variables")
+	scanCmd.Flags().StringSliceVar(&scanExcludePaths, "exclude", []string{}, "additional gitignore-style patterns to exclude from scanning")
+	scanCmd.Flags().StringSliceVar(&scanIncludes, "include", []string{}, "only scan files matching these globs, e.g. '**/*.go'")
+	scanCmd.Flags().StringSliceVar(&scanIncludeExts, "include-ext", []string{}, "additional file extensions to scan")
+	scanCmd.Flags().BoolVar(&scanShowUsages, "show-usages", false, "show where each variable is used")
+	scanCmd.Flags().IntVarP(&scanConcurrency, "concurrency", "j", 0, "number of files to scan in parallel (default: number of CPUs)")
+	scanCmd.Flags().DurationVar(&scanTimeout, "timeout", 0, "give up scanning after this long, e.g. 30s (default: no timeout)")
+	scanCmd.Flags().BoolVar(&noCacheFlag, "no-cache", false, "scan every file instead of reusing results from "+scan.CacheDir)
+	scanCmd.Flags().StringVarP(&configFlag, "config", "c", "", "path to configuration file")
+}
+
+func runScan(cmd *cobra.Command, args []string) error {
+	// Determine path to scan
+	scanPath := "."
+	if len(args) > 0 {
+		scanPath = args[0]
+	}
+
+	// Verify path exists
+	if _, err := os.Stat(scanPath); os.IsNotExist(err) {
+		return fmt.Errorf("path does not exist: %s", scanPath)
+	}
+
+	switch scanOutput {
+	case "pretty", "json", "list":
+	default:
+		return fmt.Errorf("unknown output format %q (expected pretty, json or list)", scanOutput)
+	}
+
+	// Flags take precedence over the config file
+	cfg := config.Load(configFlag)
+	if !cmd.Flags().Changed("min-confidence") {
+		scanMinConfidence = cfg.Scan.MinConfidence
+	}
+	if !cmd.Flags().Changed("min-usages") {
+		scanMinUsages = cfg.Scan.MinUsages
+	}
+	if cmd.Flags().Changed("concurrency") {
+		cfg.Scan.Concurrency = scanConcurrency
+	}
+
+	ctx := cmd.Context()
+	if scanTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, scanTimeout)
+		defer cancel()
+	}
+
+	// Create scanner
+	scanner := newProjectScanner(cfg, scanExcludePaths, scanIncludeExts, scanIncludes)
+	if !noCacheFlag {
+		scanner = scanner.WithCache(scan.OpenCache(scan.DefaultCachePath(scanPath)))
+	}
+
+	// Perform the scan
+	if scanOutput == "pretty" {
+		fmt.Printf("🔍 Scanning %s for environment variable usage...\n\n", scanPath)
+	}
+
+	result, err := scanner.ScanProjectContext(ctx, scanPath)
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+
+	// Print errors if any
+	if len(result.Errors) > 0 && scanOutput != "json" {
+		fmt.Fprintf(os.Stderr, "⚠️  Encountered %d errors during scanning:\n", len(result.Errors))
+		for _, scanErr := range result.Errors {
+			fmt.Fprintf(os.Stderr, "  • %v\n", scanErr)
+		}
+		fmt.Fprintln(os.Stderr)
+	}
+
+	// Get filtered variables; only required ones go into generated config
+	discoveredVars := result.GetDiscoveredVariables(scanMinConfidence, scanMinUsages)
+	sort.Strings(discoveredVars)
+	requiredVars := result.GetRequiredVariables(scanMinConfidence, scanMinUsages)
+	sort.Strings(requiredVars)
+
+	// Output results
+	switch scanOutput {
+	case "json":
+		err = outputJSON(result, requiredVars, scanPath)
+	case "list":
+		err = outputList(requiredVars)
+	default:
+		err = outputPretty(result, discoveredVars, len(requiredVars), scanPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Generate config suggestion
+	if scanGenerateConfig {
+		// Keep stdout clean for machine-readable output
+		status := io.Writer(os.Stdout)
+		if scanOutput != "pretty" {
+			status = os.Stderr
+		}
+		return generateConfig(requiredVars, status)
+	}
+
+	return nil
+}
+
+// newProjectScanner creates a scanner with the configured and extra
+// exclude patterns, file extensions and include globs
+func newProjectScanner(cfg config.Config, excludePaths, includeExts, includeGlobs []string) *scan.ProjectScanner {
+	scanner := scan.NewProjectScanner().
+		WithConcurrency(cfg.Scan.Concurrency).
+		WithSkipOptions(scan.SkipOptions{
+			Binary:      cfg.Scan.SkipBinary,
+			Minified:    cfg.Scan.SkipMinified,
+			MaxFileSize: cfg.Scan.MaxFileSize,
+		})
+
+	excludes := append(append(append([]string{}, cfg.Scan.Exclude...), cfg.Scan.ExcludePaths...), excludePaths...)
+	if len(excludes) > 0 {
+		scanner = scanner.WithExcludePaths(append(scanner.GetExcludePaths(), excludes...))
+	}
+
+	exts := append(append([]string{}, cfg.Scan.IncludeExtensions...), includeExts...)
+	if len(exts) > 0 {
+		scanner = scanner.WithIncludeExtensions(append(scanner.GetIncludeExtensions(), exts...))
+	}
+
+	includes := append(append([]string{}, cfg.Scan.Include...), includeGlobs...)
+	if len(includes) > 0 {
+		scanner = scanner.WithIncludePatterns(includes)
+	}
+
+	return scanner
+}
+
+func outputPretty(result *scan.ScanResult, discoveredVars []string, requiredCount int, scanPath string) error {
+	// Summary
+	fmt.Printf("📊 Scan Summary:\n")
+	if result.Cached > 0 {
+		fmt.Printf("  • Scanned %s (%d unchanged, from cache)\n", output.Plural(len(result.Files), "file"), result.Cached)
+	} else {
+		fmt.Printf("  • Scanned %s\n", output.Plural(len(result.Files), "file"))
+	}
+	if len(result.Skipped) > 0 {
+		fmt.Printf("  • Skipped %s (%s)\n", output.Plural(len(result.Skipped), "file"), describeSkipped(result.Skipped))
+		if scanShowUsages {
+			for _, skipped := range result.Skipped {
+				relPath, err := filepath.Rel(scanPath, skipped.File)
+				if err != nil {
+					relPath = skipped.File
+				}
+				reason := string(skipped.Reason)
+				if skipped.Detail != "" {
+					reason += ": " + skipped.Detail
+				}
+				fmt.Printf("    ⏭️  %s - %s\n", relPath, reason)
+			}
+		}
+	}
+	fmt.Printf("  • Found %s\n", output.Plural(len(result.Variables), "unique variable"))
+	fmt.Printf("  • %s meet criteria (confidence ≥ %.1f, usages ≥ %d), %d required\n\n",
+		output.Plural(len(discoveredVars), "variable"), scanMinConfidence, scanMinUsages, requiredCount)
+
+	if len(discoveredVars) == 0 {
+		fmt.Println("🤷 No environment variables found that meet the specified criteria.")
+		fmt.Println("💡 Try lowering --min-confidence or --min-usages to see more results.")
+		return nil
+	}
+
+	// Display required variables
+	fmt.Printf("🌿 Environment Variables Discovered:\n")
+	fmt.Println(strings.Repeat("─", 50))
+
+	for _, varName := range discoveredVars {
+		summary := result.Summarize(varName)
+
+		confidenceIcon := getConfidenceIcon(summary.Confidence)
+		fmt.Printf("%s %s (%.1f%% confidence, %s across %s) [%s] %s\n",
+			confidenceIcon, varName, summary.Confidence*100,
+			output.Plural(summary.Usages, "usage"), output.Plural(len(summary.Files), "file"),
+			strings.Join(summary.Languages, ", "), describeRequirement(summary))
+
+		// Show usage details if requested
+		if scanShowUsages {
+			for _, usage := range result.Variables[varName] {
+				relPath, err := filepath.Rel(scanPath, usage.File)
+				if err != nil {
+					relPath = usage.File
+				}
+				location := fmt.Sprintf("%s:%d", relPath, usage.Line)
+				if usage.Function != "" {
+					location += " in " + usage.Function
+				}
+				if usage.Requirement != "" && usage.Requirement != scan.RequirementRequired {
+					location += " (" + string(usage.Requirement) + ")"
+				}
+				fmt.Printf("    📍 %s - %s\n", location, truncateString(usage.Context, 60))
+			}
+		}
+	}
+
+	fmt.Println()
+
+	if scanGenerateConfig {
+		return nil
+	}
+
+	// Show next steps
+	fmt.Println("💡 Next Steps:")
+	fmt.Println("  • Review the discovered variables above")
+	fmt.Println("  • Add missing variables to your .env files")
+	fmt.Printf("  • Run: ecolint scan --generate-config  # to create configuration\n")
+	fmt.Printf("  • Run: ecolint lint --auto-discover    # to lint with discovered variables\n")
+
+	return nil
+}
+
+// describeSkipped counts skipped files by reason, e.g. "2 binary, 1 minified"
+func describeSkipped(skipped []scan.SkippedFile) string {
+	counts := make(map[scan.SkipReason]int)
+	for _, s := range skipped {
+		counts[s.Reason]++
+	}
+
+	var parts []string
+	for _, reason := range []scan.SkipReason{scan.SkipBinary, scan.SkipMinified, scan.SkipTooLarge} {
+		if counts[reason] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[reason], reason))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeRequirement formats how a variable is used, e.g. "optional, default 8080"
+func describeRequirement(summary scan.VariableSummary) string {
+	switch summary.Requirement {
+	case scan.RequirementOptional:
+		if len(summary.Defaults) > 0 {
+			return "optional, default " + strings.Join(summary.Defaults, " | ")
+		}
+		return "optional"
+	case scan.RequirementExistence:
+		return "existence check"
+	case scan.RequirementDeclared:
+		return "declared in manifest"
+	default:
+		return "required"
+	}
+}
+
+type scanSummaryJSON struct {
+	TotalVariables    int     `json:"total_variables"`
+	RequiredVariables int     `json:"required_variables"`
+	MinConfidence     float64 `json:"min_confidence"`
+	MinUsages         int     `json:"min_usages"`
+}
+
+func outputJSON(result *scan.ScanResult, requiredVars []string, scanPath string) error {
+	output := struct {
+		ScanPath     string                        `json:"scan_path"`
+		FilesScanned int                           `json:"files_scanned"`
+		Skipped      []scan.SkippedFile            `json:"skipped"`
+		Discovered   []scan.VariableSummary        `json:"discovered"`
+		Variables    map[string][]scan.UsageResult `json:"variables"`
+		Required     []string                      `json:"required_variables"`
+		Errors       []string                      `json:"errors"`
+		Summary      scanSummaryJSON               `json:"summary"`
+	}{
+		ScanPath:     scanPath,
+		FilesScanned: len(result.Files),
+		Skipped:      append([]scan.SkippedFile{}, result.Skipped...),
+		Discovered:   make([]scan.VariableSummary, 0, len(result.Variables)),
+		Variables:    result.Variables,
+		Required:     requiredVars,
+		Errors:       make([]string, len(result.Errors)),
+		Summary: scanSummaryJSON{
+			TotalVariables:    len(result.Variables),
+			RequiredVariables: len(requiredVars),
+			MinConfidence:     scanMinConfidence,
+			MinUsages:         scanMinUsages,
+		},
+	}
+
+	// Summaries of every discovered variable, sorted by name
+	names := make([]string, 0, len(result.Variables))
+	for name := range result.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		output.Discovered = append(output.Discovered, result.Summarize(name))
+	}
+
+	// Convert errors to strings
+	for i, err := range result.Errors {
+		output.Errors[i] = err.Error()
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+func outputList(requiredVars []string) error {
+	for _, varName := range requiredVars {
+		fmt.Println(varName)
+	}
+	return nil
+}
+
+func generateConfig(requiredVars []string, status io.Writer) error {
+	configPath := ".ecolint.yaml"
+
+	// Check if config already exists
+	if _, err := os.Stat(configPath); err == nil {
+		fmt.Fprintf(status, "⚠️  Configuration file %s already exists.\n", configPath)
+		fmt.Fprint(status, "Do you want to update it with discovered variables? (y/N): ")
+
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+			fmt.Fprintln(status, "Configuration update cancelled.")
+			return nil
+		}
+	}
+
+	configContent := `# ecolint configuration file
+# 🌱 cultivating clean environments
+# Generated by 'ecolint scan --generate-config'
+
+# Environment variables your code reads without a default
+required_vars:
+`
+
+	for _, varName := range requiredVars {
+		configContent += fmt.Sprintf("  - %s\n", varName)
+	}
+
+	configContent += `
+# Rule configuration
+rules:
+  duplicate: true      # Check for duplicate variable definitions
+  missing: true        # Check for missing required variables (auto-discovered)
+  syntax: true         # Validate .env file syntax
+  empty_values: true   # Warn about empty variable values
+  security: true       # Check for potential secrets in plaintext
+  convention: true     # Enforce naming conventions
+  deployment: false    # Compare with variables set by Kubernetes, docker-compose and Helm manifests
+  unused: false        # Report variables that no source file reads
+  consistency: false   # Report keys missing from some variants, e.g. .env.staging vs .env.production
+  example: false       # Report keys missing from .env.example, or set there but not in the file
+
+# Output configuration
+output:
+  format: "pretty"     # Output format: pretty, json, github, markdown, html, template
+  color: true          # Enable colored output
+
+# Auto-discovery settings (for future scans)
+scan:
+  min_confidence: ` + fmt.Sprintf("%.1f", scanMinConfidence) + `  # Minimum confidence for auto-discovered variables
+  min_usages: ` + fmt.Sprintf("%d", scanMinUsages) + `         # Minimum usage count for auto-discovered variables
+  exclude:             # Additional gitignore-style patterns to exclude from scanning
+    - "testdata/"
+    - "*.min.js"
+`
+
+	// Write the configuration
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		return fmt.Errorf("failed to write configuration: %w", err)
+	}
+
+	fmt.Fprintf(status, "✅ Generated %s with %s\n", configPath, output.Plural(len(requiredVars), "required variable"))
+	fmt.Fprintln(status, "💡 You can now run 'ecolint lint' to check your .env files!")
+
+	return nil
+}
+
+func getConfidenceIcon(confidence float64) string {
+	if confidence >= 0.9 {
+		return "🟢" // High confidence
+	} else if confidence >= 0.7 {
+		return "🟡" // Medium confidence
+	} else {
+		return "🔴" // Low confidence
+	}
+}
+
+func truncateString(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/tahcohcat/ecolint/domain/schema"
+	"github.com/tahcohcat/ecolint/internal/config"
+	"github.com/tahcohcat/ecolint/internal/output"
+	"github.com/tahcohcat/ecolint/internal/scan"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "📐 Work with the environment schema",
+	Long: `📐 Work with the environment schema
+
+The environment contract is made of required_vars, the schema: section
+(plus schema_file) of .ecolint.yaml and, unless --no-scan is given, the
+variables a project scan discovers.`,
+}
+
+var schemaExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "📤 Export the environment contract as a JSON Schema",
+	Long: `📤 Export the environment contract as a JSON Schema
+
+Writes a JSON Schema describing every variable in required_vars and
+schema:, and every variable the project scan discovers. Values are
+described after conversion, so an int is an "integer"; schema_file:
+reads the result back.
+
+Examples:
+  ecolint schema export                       # print to stdout
+  ecolint schema export -o env.schema.json    # write to a file
+  ecolint schema export --no-scan             # only what the config declares`,
+	Args: cobra.NoArgs,
+	RunE: runSchemaExport,
+}
+
+var (
+	schemaOutputFlag string
+	noScanFlag       bool
+)
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+	schemaCmd.AddCommand(schemaExportCmd)
+
+	schemaExportCmd.Flags().StringVarP(&schemaOutputFlag, "output", "o", "", "file to write (default: stdout)")
+	addContractFlags(schemaExportCmd)
+}
+
+// addContractFlags registers the flags loadContract reads
+func addContractFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&configFlag, "config", "c", "", "path to configuration file")
+	cmd.Flags().StringVar(&scanPathFlag, "scan-path", ".", "path to scan for discovered variables")
+	cmd.Flags().BoolVar(&noScanFlag, "no-scan", false, "only use variables declared in the configuration")
+	cmd.Flags().BoolVar(&noCacheFlag, "no-cache", false, "scan every file instead of reusing results from "+scan.CacheDir)
+}
+
+func runSchemaExport(cmd *cobra.Command, args []string) error {
+	contract, _, err := loadContract(cmd)
+	if err != nil {
+		return err
+	}
+
+	data, err := contract.JSONSchema("Environment")
+	if err != nil {
+		return err
+	}
+
+	if schemaOutputFlag == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(schemaOutputFlag, data, 0644); err != nil {
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "✅ Wrote %s with %s\n", schemaOutputFlag, output.Plural(len(contract), "variable"))
+	return nil
+}
+
+// loadContract combines schema_file, schema:, required_vars and, unless
+// --no-scan is set, the variables a project scan discovers. Configured
+// entries come first in config order, then discovered variables by name.
+func loadContract(cmd *cobra.Command) (schema.Schema, *scan.ScanResult, error) {
+	cfg := config.Load(configFlag)
+
+	contract, err := configuredSchema(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	contract = append(schema.Schema{}, contract...)
+
+	index := func(name string) int {
+		for i, f := range contract {
+			if f.Name == name {
+				return i
+			}
+		}
+		return -1
+	}
+
+	for _, name := range cfg.RequiredVars {
+		if i := index(name); i >= 0 {
+			contract[i].Required = true
+		} else {
+			contract = append(contract, schema.Field{Name: name, Type: schema.TypeString, Required: true})
+		}
+	}
+
+	if noScanFlag {
+		return contract, nil, nil
+	}
+
+	scanner := newProjectScanner(cfg, nil, nil, nil)
+	if !noCacheFlag {
+		scanner = scanner.WithCache(scan.OpenCache(scan.DefaultCachePath(scanPathFlag)))
+	}
+	result, err := scanner.ScanProjectContext(cmd.Context(), scanPathFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("project scan failed: %w", err)
+	}
+
+	discovered := result.GetDiscoveredVariables(cfg.Scan.MinConfidence, cfg.Scan.MinUsages)
+	sort.Strings(discovered)
+	for _, name := range discovered {
+		summary := result.Summarize(name)
+
+		var def string
+		if len(summary.Defaults) == 1 {
+			def = summary.Defaults[0]
+		}
+
+		if i := index(name); i >= 0 {
+			// Configured entries win; the scan only fills in the default
+			if contract[i].Default == "" && def != "" && contract[i].Validate(def) == nil {
+				contract[i].Default = def
+			}
+			continue
+		}
+		contract = append(contract, schema.Field{
+			Name:     name,
+			Type:     schema.TypeString,
+			Required: summary.Requirement == scan.RequirementRequired,
+			Default:  def,
+		})
+	}
+
+	return contract, result, nil
+}
+
+// configuredSchema combines schema_file with the schema: section
+func configuredSchema(cfg config.Config) (schema.Schema, error) {
+	contract := cfg.Schema
+	if cfg.SchemaFile != "" {
+		imported, err := schema.LoadJSONSchema(cfg.Resolve(cfg.SchemaFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load schema_file: %w", err)
+		}
+		contract = imported.Merge(cfg.Schema)
+	}
+	if err := contract.Check(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return contract, nil
+}
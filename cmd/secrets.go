@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tahcohcat/ecolint/internal/config"
+	"github.com/tahcohcat/ecolint/parse"
+	"github.com/tahcohcat/ecolint/rules"
+)
+
+var secretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "🔐 Manage secret detection",
+	Long: `🔐 Manage secret detection
+
+Values the security rule reports that are public on purpose, such as
+publishable Stripe keys or OAuth client IDs, can be allowed under
+security.allow in .ecolint.yaml, by variable name, value hash or pattern.`,
+}
+
+var secretsAllowCmd = &cobra.Command{
+	Use:   "allow KEY",
+	Short: "✅ Allow a variable's current value",
+	Long: `✅ Allow a variable's current value
+
+Adds the variable's name and the SHA-256 of its value to security.allow,
+so the security rule stops reporting it. The value itself is never
+written to the config. If the value changes, the entry no longer matches
+and 'ecolint lint' reports it as unused.
+
+Examples:
+  ecolint secrets allow STRIPE_PUBLISHABLE_KEY                 # value from .env
+  ecolint secrets allow GOOGLE_CLIENT_ID --file .env.production
+  ecolint secrets allow SENTRY_DSN --reason "public DSN"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSecretsAllow,
+}
+
+var (
+	secretsFileFlag   string
+	secretsReasonFlag string
+)
+
+func init() {
+	rootCmd.AddCommand(secretsCmd)
+	secretsCmd.AddCommand(secretsAllowCmd)
+
+	secretsAllowCmd.Flags().StringVarP(&secretsFileFlag, "file", "f", ".env", "env file to read the value from")
+	secretsAllowCmd.Flags().StringVar(&secretsReasonFlag, "reason", "", "why the value may be committed")
+	secretsAllowCmd.Flags().StringVarP(&configFlag, "config", "c", "", "configuration file to update (default: .ecolint.yaml)")
+}
+
+func runSecretsAllow(cmd *cobra.Command, args []string) error {
+	key := args[0]
+
+	vars, err := parse.NewEnhanced().Parse(secretsFileFlag)
+	if err != nil {
+		return err
+	}
+	var value string
+	found := false
+	for _, v := range vars {
+		if v.Key == key {
+			value, found = v.Value, true
+		}
+	}
+	if !found {
+		return fmt.Errorf("%s is not set in %s", key, secretsFileFlag)
+	}
+
+	cfg := config.Load(configFlag)
+	path := cfg.Path
+	if path == "" {
+		path = configFlag
+	}
+	if path == "" {
+		path = ".ecolint.yaml"
+	}
+
+	entry := rules.AllowEntry{Key: key, SHA256: rules.HashValue(value), Reason: secretsReasonFlag}
+	for _, existing := range cfg.Security.Allow {
+		if existing.Key == entry.Key && strings.EqualFold(existing.SHA256, entry.SHA256) {
+			fmt.Printf("✅ %s is already allowed in %s\n", key, path)
+			return nil
+		}
+	}
+
+	if err := config.AddAllowEntry(path, entry); err != nil {
+		return fmt.Errorf("failed to update %s: %w", path, err)
+	}
+	fmt.Printf("🔐 Allowed %s (sha256 %s…) in %s\n", key, entry.SHA256[:12], path)
+	return nil
+}
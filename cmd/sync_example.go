@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tahcohcat/ecolint/domain/schema"
+	"github.com/tahcohcat/ecolint/internal/config"
+	"github.com/tahcohcat/ecolint/internal/example"
+	"github.com/tahcohcat/ecolint/internal/output"
+	"github.com/tahcohcat/ecolint/parse"
+	"github.com/tahcohcat/ecolint/rules"
+)
+
+var syncExampleCmd = &cobra.Command{
+	Use:   "sync-example [files...]",
+	Short: "📋 Update .env.example from your environment files",
+	Long: `📋 Update .env.example from your environment files
+
+Regenerates the template next to your env files (.env.example unless
+example.template says otherwise) so it lists every key they set, without
+their values.
+
+• Existing lines, comments and ordering in the template are kept
+• Keys no env file sets any more are removed, with the comment above them;
+  when files are given as arguments keys are only added, since other env
+  files may still set them
+• New keys are appended with the schema default as their value, or an
+  empty one, and the schema description or type as a comment
+
+Without arguments every .env* file in the current directory is used,
+except .env.local, .env.*.local and files listed under
+consistency.allow_differ. With --recursive each directory gets its own
+template.
+
+Examples:
+  ecolint sync-example                    # update ./.env.example
+  ecolint sync-example .env .env.prod     # only use these files
+  ecolint sync-example --recursive        # every directory with .env files
+  ecolint sync-example --dry-run          # show what would change`,
+	RunE: runSyncExample,
+}
+
+func init() {
+	rootCmd.AddCommand(syncExampleCmd)
+
+	syncExampleCmd.Flags().StringVarP(&configFlag, "config", "c", "", "path to configuration file")
+	syncExampleCmd.Flags().BoolVarP(&recursiveFlag, "recursive", "r", false, "recursively search for .env files")
+	syncExampleCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "preview changes without applying them")
+}
+
+func runSyncExample(cmd *cobra.Command, args []string) error {
+	cfg := config.Load(configFlag)
+	contract, err := configuredSchema(cfg)
+	if err != nil {
+		return err
+	}
+
+	template := cfg.Example.Template
+	if template == "" {
+		template = ".env.example"
+	}
+	allowDiffer := append(append([]string{}, rules.DefaultAllowDiffer...), cfg.Consistency.AllowDiffer...)
+
+	files, err := exampleSources(args, template, allowDiffer)
+	if err != nil {
+		return fmt.Errorf("error finding files: %w", err)
+	}
+	if len(files) == 0 {
+		fmt.Println("🤷 No .env files found to sync")
+		return nil
+	}
+
+	// One template per directory
+	byDir := make(map[string][]string)
+	var dirs []string
+	for _, file := range files {
+		dir := filepath.Dir(file)
+		if _, ok := byDir[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+		byDir[dir] = append(byDir[dir], file)
+	}
+	sort.Strings(dirs)
+
+	changed := 0
+	for _, dir := range dirs {
+		updated, err := syncExample(filepath.Join(dir, template), byDir[dir], contract, len(args) > 0)
+		if err != nil {
+			fmt.Printf("❌ Error syncing %s: %v\n", filepath.Join(dir, template), err)
+			continue
+		}
+		if updated {
+			changed++
+		}
+	}
+
+	switch {
+	case changed == 0:
+	case dryRunFlag:
+		fmt.Printf("\n🔍 Dry run complete. %s would change\n", output.Plural(changed, "template"))
+		fmt.Println("💡 Run without --dry-run to apply changes")
+	default:
+		fmt.Printf("\n✅ Updated %s\n", output.Plural(changed, "template"))
+	}
+	return nil
+}
+
+// syncExample rewrites one template from the files next to it and reports
+// whether it changed. With addOnly, keys the files don't set are kept.
+func syncExample(templatePath string, files []string, contract schema.Schema, addOnly bool) (bool, error) {
+	parser := parse.NewEnhanced()
+
+	// Keys in order of first appearance, .env first
+	sort.SliceStable(files, func(i, j int) bool {
+		return filepath.Base(files[i]) == ".env" && filepath.Base(files[j]) != ".env"
+	})
+	var keys []string
+	seen := make(map[string]bool)
+	for _, file := range files {
+		vars, err := parser.Parse(file)
+		if err != nil {
+			return false, err
+		}
+		for _, v := range vars {
+			if !seen[v.Key] {
+				seen[v.Key] = true
+				keys = append(keys, v.Key)
+			}
+		}
+	}
+
+	var lines []string
+	data, err := os.ReadFile(templatePath)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return false, err
+	default:
+		content := strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+		if content != "" {
+			lines = strings.Split(content, "\n")
+		}
+	}
+
+	if addOnly {
+		// Keep every key the template lists, then add the files' keys
+		var all []string
+		listed := make(map[string]bool)
+		for _, line := range lines {
+			if key, ok := example.Key(line); ok && !listed[key] {
+				listed[key] = true
+				all = append(all, key)
+			}
+		}
+		for _, key := range keys {
+			if !listed[key] {
+				all = append(all, key)
+			}
+		}
+		keys = all
+	}
+
+	result := example.Sync(lines, keys, func(key string) (string, string) {
+		field, ok := contract.Field(key)
+		if !ok {
+			return "", ""
+		}
+		comment := field.Description
+		if comment == "" && field.Type != schema.TypeString {
+			comment = describeField(field)
+		}
+		return field.Default, comment
+	})
+
+	if !result.Changed() {
+		fmt.Printf("✅ %s is up to date\n", templatePath)
+		return false, nil
+	}
+
+	verb := "Updated"
+	if dryRunFlag {
+		verb = "Would update"
+	}
+	fmt.Printf("📋 %s %s\n", verb, templatePath)
+	for _, key := range result.Added {
+		fmt.Printf("  ➕ %s\n", key)
+	}
+	for _, key := range result.Removed {
+		fmt.Printf("  ➖ %s\n", key)
+	}
+
+	if dryRunFlag {
+		return true, nil
+	}
+	content := strings.Join(result.Lines, "\n") + "\n"
+	if err := os.WriteFile(templatePath, []byte(content), 0644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// exampleSources lists the env files a template is generated from
+func exampleSources(args []string, template string, allowDiffer []string) ([]string, error) {
+	var candidates []string
+	if len(args) == 0 && !recursiveFlag {
+		entries, err := os.ReadDir(".")
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && isEnvFile(entry.Name()) {
+				candidates = append(candidates, entry.Name())
+			}
+		}
+	} else {
+		var err error
+		candidates, err = getFilesToLint(args, recursiveFlag)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var files []string
+	for _, file := range candidates {
+		name := filepath.Base(file)
+		if name == template || rules.MatchesAny(name, allowDiffer) {
+			continue
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
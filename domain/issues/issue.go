@@ -12,6 +12,14 @@ type Issue struct {
 	Name string
 	File string
 
+	// Value is the offending value, when the rule reports one. Output
+	// formatters pass it through the redaction layer before printing.
+	Value string `json:",omitempty"`
+
+	// Confidence is how sure a rule that guesses, such as the security
+	// rule, is about the issue: "low", "medium" or "high"
+	Confidence string `json:",omitempty"`
+
 	Recommendations []string
 }
 
@@ -26,9 +34,21 @@ func NewIssue(name, key, file string, fl, ll int, r []string) Issue {
 	}
 }
 
+// WithValue returns a copy of the issue carrying the offending value
+func (i Issue) WithValue(value string) Issue {
+	i.Value = value
+	return i
+}
+
+// WithConfidence returns a copy of the issue with the rule's confidence
+func (i Issue) WithConfidence(confidence string) Issue {
+	i.Confidence = confidence
+	return i
+}
+
 func (i Issue) String() string {
-	if i.Recommendations == nil && len(i.Recommendations) > 0 {
-		fmt.Sprintf("%s %q found (line %d and line %d). Recommendations: %s",
+	if len(i.Recommendations) > 0 {
+		return fmt.Sprintf("%s %q found (line %d and line %d). Recommendations: %s",
 			i.Name, i.Key, i.FirstLine, i.Line, i.Recommendations)
 	}
 
@@ -0,0 +1,180 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// durationPattern matches values time.ParseDuration accepts
+const durationPattern = `^[-+]?(?:[0-9]*\.?[0-9]+(?:ns|us|µs|ms|s|m|h))+$|^0$`
+
+// object is a JSON object that keeps its keys in insertion order
+type object []member
+
+type member struct {
+	key   string
+	value interface{}
+}
+
+func (o *object) set(key string, value interface{}) {
+	*o = append(*o, member{key, value})
+}
+
+func (o object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(m.key)
+		buf.Write(key)
+		buf.WriteByte(':')
+		value, err := json.Marshal(m.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// JSONSchema converts the schema into a JSON Schema describing the
+// environment as an object of converted values, in field order.
+// ParseJSONSchema reads it back.
+func (s Schema) JSONSchema(title string) ([]byte, error) {
+	properties := object{}
+	required := []string{}
+	for _, f := range s {
+		properties.set(f.Name, f.jsonProperty())
+		if f.Required {
+			required = append(required, f.Name)
+		}
+	}
+
+	root := object{}
+	root.set("$schema", "https://json-schema.org/draft/2020-12/schema")
+	root.set("title", title)
+	root.set("type", "object")
+	root.set("properties", properties)
+	root.set("required", required)
+
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func (f Field) jsonProperty() object {
+	prop := object{}
+	if f.Description != "" {
+		prop.set("description", f.Description)
+	}
+
+	switch f.Type {
+	case TypeInt, TypePort:
+		prop.set("type", "integer")
+		min, max := f.Min, f.Max
+		if f.Type == TypePort {
+			min, max = tighter(min, "1", false), tighter(max, "65535", true)
+		}
+		setNumber(&prop, "minimum", min)
+		setNumber(&prop, "maximum", max)
+	case TypeFloat:
+		prop.set("type", "number")
+		setNumber(&prop, "minimum", f.Min)
+		setNumber(&prop, "maximum", f.Max)
+	case TypeBool:
+		prop.set("type", "boolean")
+	case TypeEnum:
+		prop.set("type", "string")
+		prop.set("enum", f.Values)
+	default:
+		prop.set("type", "string")
+		pattern := ""
+		switch f.Type {
+		case TypeURL:
+			prop.set("format", "uri")
+			if len(f.Schemes) > 0 {
+				pattern = "^(?:" + strings.Join(f.Schemes, "|") + "):"
+			}
+		case TypeEmail:
+			prop.set("format", "email")
+		case TypeHostname:
+			prop.set("format", "hostname")
+		case TypeIP:
+			prop.set("anyOf", []object{{{"format", "ipv4"}}, {{"format", "ipv6"}}})
+		case TypeBase64:
+			prop.set("contentEncoding", "base64")
+		case TypeJSON:
+			prop.set("contentMediaType", "application/json")
+		case TypeDuration:
+			pattern = durationPattern
+		case TypeCIDR:
+			pattern = `^[0-9A-Fa-f:.]+/[0-9]{1,3}$`
+		}
+		if f.Type != TypeDuration {
+			setLength(&prop, "minLength", f.Min)
+			setLength(&prop, "maxLength", f.Max)
+		}
+		// JSON Schema has a single pattern, so the field's own wins
+		if f.Pattern != "" {
+			pattern = "^(?:" + f.Pattern + ")$"
+		}
+		if pattern != "" {
+			prop.set("pattern", pattern)
+		}
+	}
+
+	if f.Default != "" {
+		prop.set("default", f.jsonDefault())
+	}
+	return prop
+}
+
+// jsonDefault converts the default to the property's JSON type
+func (f Field) jsonDefault() interface{} {
+	switch f.Type {
+	case TypeInt, TypePort, TypeFloat:
+		if n, err := strconv.ParseFloat(f.Default, 64); err == nil {
+			return n
+		}
+	case TypeBool:
+		switch strings.ToLower(f.Default) {
+		case "true", "1", "yes", "on":
+			return true
+		case "false", "0", "no", "off":
+			return false
+		}
+	}
+	return f.Default
+}
+
+// tighter returns the stricter of a configured bound and a type's own
+func tighter(bound, limit string, upper bool) string {
+	if bound == "" {
+		return limit
+	}
+	b, _ := strconv.ParseFloat(bound, 64)
+	l, _ := strconv.ParseFloat(limit, 64)
+	if upper == (b < l) {
+		return bound
+	}
+	return limit
+}
+
+func setNumber(prop *object, key, bound string) {
+	if n, err := strconv.ParseFloat(bound, 64); err == nil {
+		prop.set(key, n)
+	}
+}
+
+func setLength(prop *object, key, bound string) {
+	if n, err := strconv.Atoi(bound); err == nil {
+		prop.set(key, n)
+	}
+}
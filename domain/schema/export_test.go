@@ -0,0 +1,83 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestJSONSchemaRoundTrip(t *testing.T) {
+	s := Schema{
+		{Name: "PORT", Type: TypePort, Min: "1024", Required: true, Default: "8080", Description: "HTTP listen port"},
+		{Name: "LOG_LEVEL", Type: TypeEnum, Values: []string{"debug", "info"}, Default: "info"},
+		{Name: "DATABASE_URL", Type: TypeURL, Schemes: []string{"postgres"}, Required: true},
+		{Name: "TIMEOUT", Type: TypeDuration, Default: "30s"},
+		{Name: "DEBUG", Type: TypeBool, Default: "false"},
+		{Name: "API_TOKEN", Type: TypeString, Min: "32", Pattern: `tok_\w+`},
+		{Name: "FEATURES", Type: TypeJSON},
+		{Name: "ALLOWED_NET", Type: TypeCIDR},
+	}
+
+	data, err := s.JSONSchema("Environment")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !json.Valid(data) {
+		t.Fatalf("invalid JSON:\n%s", data)
+	}
+
+	back, err := ParseJSONSchema(data)
+	if err != nil {
+		t.Fatalf("ParseJSONSchema() = %v\n%s", err, data)
+	}
+
+	var names []string
+	for _, f := range back {
+		names = append(names, f.Name)
+	}
+	want := []string{"PORT", "LOG_LEVEL", "DATABASE_URL", "TIMEOUT", "DEBUG", "API_TOKEN", "FEATURES", "ALLOWED_NET"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("properties in order %v, want %v", names, want)
+	}
+	if req := back.Required(); !reflect.DeepEqual(req, []string{"PORT", "DATABASE_URL"}) {
+		t.Errorf("Required() = %v", req)
+	}
+	if port, _ := back.Field("PORT"); port.Default != "8080" || port.Description != "HTTP listen port" {
+		t.Errorf("PORT = %+v, want default and description preserved", port)
+	}
+
+	// The exported schema accepts and rejects the same values
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"PORT", "8080"},
+		{"PORT", "80"},
+		{"PORT", "70000"},
+		{"LOG_LEVEL", "info"},
+		{"LOG_LEVEL", "verbose"},
+		{"DATABASE_URL", "postgres://db/app"},
+		{"DATABASE_URL", "mysql://db/app"},
+		{"TIMEOUT", "1m30s"},
+		{"TIMEOUT", "30"},
+		{"DEBUG", "true"},
+		{"DEBUG", "maybe"},
+		{"API_TOKEN", "tok_0123456789abcdef0123456789abcdef"},
+		{"API_TOKEN", "tok_short"},
+		{"FEATURES", `{"beta": true}`},
+		{"FEATURES", "beta"},
+		{"ALLOWED_NET", "10.0.0.0/8"},
+		{"ALLOWED_NET", "10.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"="+tt.value, func(t *testing.T) {
+			original, _ := s.Field(tt.name)
+			exported, _ := back.Field(tt.name)
+			wantValid := original.Validate(tt.value) == nil
+			if gotValid := exported.Validate(tt.value) == nil; gotValid != wantValid {
+				t.Errorf("exported %s accepts %q: %v, original: %v", tt.name, tt.value, gotValid, wantValid)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonSchema is the subset of JSON Schema that maps onto env variables
+type jsonSchema struct {
+	Ref              string                 `json:"$ref"`
+	Type             interface{}            `json:"type"` // a type name or a list of them
+	Description      string                 `json:"description"`
+	Properties       map[string]*jsonSchema `json:"properties"`
+	Required         []string               `json:"required"`
+	Enum             []interface{}          `json:"enum"`
+	Pattern          string                 `json:"pattern"`
+	Format           string                 `json:"format"`
+	ContentEncoding  string                 `json:"contentEncoding"`
+	ContentMediaType string                 `json:"contentMediaType"`
+	Default          interface{}            `json:"default"`
+	Minimum          *float64               `json:"minimum"`
+	Maximum          *float64               `json:"maximum"`
+	MinLength        *int                   `json:"minLength"`
+	MaxLength        *int                   `json:"maxLength"`
+	Definitions      map[string]*jsonSchema `json:"definitions"`
+	Defs             map[string]*jsonSchema `json:"$defs"`
+}
+
+// formatTypes maps JSON Schema string formats onto schema types
+var formatTypes = map[string]Type{
+	"uri":      TypeURL,
+	"url":      TypeURL,
+	"email":    TypeEmail,
+	"hostname": TypeHostname,
+	"ipv4":     TypeIP,
+	"ipv6":     TypeIP,
+	"regex":    TypeString,
+}
+
+// LoadJSONSchema reads a JSON Schema file, see ParseJSONSchema
+func LoadJSONSchema(path string) (Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := ParseJSONSchema(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// ParseJSONSchema converts a JSON Schema describing the environment as an
+// object into a Schema. Each property becomes a field: its type, enum,
+// pattern, format, minimum/maximum and minLength/maxLength become the
+// field's type and constraints, and the required list marks fields required.
+// Since env values are strings, "integer" accepts "8080" and "boolean"
+// accepts "true". Local $refs to definitions are followed.
+func ParseJSONSchema(data []byte) (Schema, error) {
+	var root jsonSchema
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid JSON Schema: %w", err)
+	}
+	if len(root.Properties) == 0 {
+		return nil, fmt.Errorf("JSON Schema has no properties")
+	}
+
+	required := make(map[string]bool)
+	for _, name := range root.Required {
+		required[name] = true
+	}
+
+	var s Schema
+	for _, name := range propertyOrder(data, root.Properties) {
+		prop, err := root.resolve(root.Properties[name], 0)
+		if err != nil {
+			return nil, fmt.Errorf("property %s: %w", name, err)
+		}
+		field, err := prop.field(name)
+		if err != nil {
+			return nil, fmt.Errorf("property %s: %w", name, err)
+		}
+		field.Required = required[name]
+		s = append(s, field)
+	}
+	return s, s.Check()
+}
+
+// resolve follows $ref to the root's definitions or $defs
+func (root *jsonSchema) resolve(prop *jsonSchema, depth int) (*jsonSchema, error) {
+	if prop == nil || prop.Ref == "" {
+		return prop, nil
+	}
+	if depth > 10 {
+		return nil, fmt.Errorf("$ref %s is circular", prop.Ref)
+	}
+
+	var defs map[string]*jsonSchema
+	var name string
+	switch {
+	case strings.HasPrefix(prop.Ref, "#/definitions/"):
+		defs, name = root.Definitions, strings.TrimPrefix(prop.Ref, "#/definitions/")
+	case strings.HasPrefix(prop.Ref, "#/$defs/"):
+		defs, name = root.Defs, strings.TrimPrefix(prop.Ref, "#/$defs/")
+	default:
+		return nil, fmt.Errorf("only local $refs to definitions are supported, got %s", prop.Ref)
+	}
+
+	def, ok := defs[name]
+	if !ok {
+		return nil, fmt.Errorf("$ref %s not found", prop.Ref)
+	}
+	resolved, err := root.resolve(def, depth+1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keywords next to $ref, such as a description, win over the definition
+	merged := *resolved
+	if prop.Description != "" {
+		merged.Description = prop.Description
+	}
+	return &merged, nil
+}
+
+func (js *jsonSchema) field(name string) (Field, error) {
+	if js == nil {
+		return Field{Name: name, Type: TypeString}, nil
+	}
+	field := Field{Name: name, Description: js.Description}
+
+	jsonType, err := js.typeName()
+	if err != nil {
+		return field, err
+	}
+
+	switch jsonType {
+	case "integer":
+		field.Type = TypeInt
+	case "number":
+		field.Type = TypeFloat
+	case "boolean":
+		field.Type = TypeBool
+	case "string", "":
+		field.Type = TypeString
+		if t, ok := formatTypes[js.Format]; ok {
+			field.Type = t
+		}
+		if js.ContentEncoding == "base64" {
+			field.Type = TypeBase64
+		}
+		if js.ContentMediaType == "application/json" {
+			field.Type = TypeJSON
+		}
+	case "object", "array":
+		// Structured values are passed as JSON strings
+		field.Type = TypeJSON
+	default:
+		return field, fmt.Errorf("unsupported type %q", jsonType)
+	}
+
+	if len(js.Enum) > 0 {
+		field.Type = TypeEnum
+		for _, v := range js.Enum {
+			field.Values = append(field.Values, enumValue(v))
+		}
+	}
+
+	switch field.Type {
+	case TypeInt, TypeFloat:
+		field.Min = formatBound(js.Minimum)
+		field.Max = formatBound(js.Maximum)
+	case TypeEnum, TypeBool:
+	default:
+		if js.MinLength != nil {
+			field.Min = strconv.Itoa(*js.MinLength)
+		}
+		if js.MaxLength != nil {
+			field.Max = strconv.Itoa(*js.MaxLength)
+		}
+	}
+
+	if js.Default != nil {
+		field.Default = enumValue(js.Default)
+	}
+
+	// JSON Schema patterns match anywhere in the value, schema patterns
+	// must match all of it
+	switch p := js.Pattern; {
+	case p == "":
+	case strings.HasPrefix(p, "^") && strings.HasSuffix(p, "$"):
+		field.Pattern = p
+	default:
+		field.Pattern = `.*(?:` + p + `).*`
+	}
+
+	return field, nil
+}
+
+// typeName returns the type, ignoring "null" in a list of types
+func (js *jsonSchema) typeName() (string, error) {
+	switch t := js.Type.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return t, nil
+	case []interface{}:
+		for _, item := range t {
+			if name, ok := item.(string); ok && name != "null" {
+				return name, nil
+			}
+		}
+		return "", nil
+	default:
+		return "", fmt.Errorf("invalid type %v", t)
+	}
+}
+
+func enumValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+func formatBound(n *float64) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*n, 'f', -1, 64)
+}
+
+// propertyOrder returns property names in the order they appear in the
+// document, since decoding into a map loses it
+func propertyOrder(data []byte, properties map[string]*jsonSchema) []string {
+	var root struct {
+		Properties json.RawMessage `json:"properties"`
+	}
+	json.Unmarshal(data, &root)
+
+	var names []string
+	dec := json.NewDecoder(strings.NewReader(string(root.Properties)))
+	if _, err := dec.Token(); err == nil {
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				break
+			}
+			names = append(names, fmt.Sprint(key))
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				break
+			}
+		}
+	}
+
+	if len(names) != len(properties) {
+		names = names[:0]
+		for name := range properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	return names
+}
@@ -0,0 +1,121 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+const serviceSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["DATABASE_URL", "PORT"],
+  "properties": {
+    "PORT": {"$ref": "#/$defs/port", "description": "HTTP listen port"},
+    "DATABASE_URL": {"type": "string", "format": "uri", "pattern": "^postgres(ql)?://"},
+    "LOG_LEVEL": {"type": "string", "enum": ["debug", "info", "warn", "error"]},
+    "WORKERS": {"type": ["integer", "null"], "minimum": 1, "maximum": 64},
+    "RATIO": {"type": "number", "maximum": 1},
+    "DEBUG": {"type": "boolean"},
+    "API_TOKEN": {"type": "string", "minLength": 32, "pattern": "^tok_"},
+    "SIGNING_KEY": {"type": "string", "contentEncoding": "base64"},
+    "FEATURES": {"type": "object"},
+    "ADMIN_EMAIL": {"type": "string", "format": "email"}
+  },
+  "$defs": {
+    "port": {"type": "integer", "minimum": 1, "maximum": 65535}
+  }
+}`
+
+func TestParseJSONSchema(t *testing.T) {
+	s, err := ParseJSONSchema([]byte(serviceSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, f := range s {
+		names = append(names, f.Name)
+	}
+	want := []string{"PORT", "DATABASE_URL", "LOG_LEVEL", "WORKERS", "RATIO", "DEBUG", "API_TOKEN", "SIGNING_KEY", "FEATURES", "ADMIN_EMAIL"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("properties in order %v, want %v", names, want)
+	}
+	if req := s.Required(); !reflect.DeepEqual(req, []string{"PORT", "DATABASE_URL"}) {
+		t.Errorf("Required() = %v", req)
+	}
+	if port, _ := s.Field("PORT"); port.Type != TypeInt || port.Description != "HTTP listen port" {
+		t.Errorf("PORT = %+v, want int with the description next to $ref", port)
+	}
+
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"PORT", "8080", true},
+		{"PORT", "80a", false},
+		{"PORT", "70000", false},
+		{"DATABASE_URL", "postgres://db:5432/app", true},
+		{"DATABASE_URL", "mysql://db/app", false},
+		{"LOG_LEVEL", "info", true},
+		{"LOG_LEVEL", "verbose", false},
+		{"WORKERS", "8", true},
+		{"WORKERS", "0", false},
+		{"RATIO", "0.5", true},
+		{"RATIO", "1.5", false},
+		{"DEBUG", "true", true},
+		{"DEBUG", "sometimes", false},
+		{"API_TOKEN", "tok_0123456789abcdef0123456789abcdef", true},
+		{"API_TOKEN", "tok_short", false},
+		{"API_TOKEN", "key_0123456789abcdef0123456789abcdef", false},
+		{"SIGNING_KEY", "c2VjcmV0", true},
+		{"FEATURES", `{"beta": true}`, true},
+		{"FEATURES", "beta", false},
+		{"ADMIN_EMAIL", "ops@example.com", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"="+tt.value, func(t *testing.T) {
+			field, ok := s.Field(tt.name)
+			if !ok {
+				t.Fatalf("no field for %s", tt.name)
+			}
+			if err := field.Validate(tt.value); (err == nil) != tt.valid {
+				t.Errorf("Validate(%q) = %v, want valid %v", tt.value, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestParseJSONSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{"not JSON", `{`},
+		{"no properties", `{"type": "object"}`},
+		{"remote ref", `{"properties": {"A": {"$ref": "https://example.com/a.json"}}}`},
+		{"missing ref", `{"properties": {"A": {"$ref": "#/definitions/b"}}}`},
+		{"circular ref", `{"properties": {"A": {"$ref": "#/definitions/a"}}, "definitions": {"a": {"$ref": "#/definitions/a"}}}`},
+		{"bad pattern", `{"properties": {"A": {"type": "string", "pattern": "("}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseJSONSchema([]byte(tt.schema)); err == nil {
+				t.Errorf("ParseJSONSchema(%s) succeeded, want an error", tt.schema)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	imported := Schema{{Name: "PORT", Type: TypeInt}, {Name: "HOST", Type: TypeHostname}}
+	inline := Schema{{Name: "PORT", Type: TypePort}, {Name: "TZ", Type: TypeString}}
+
+	got := imported.Merge(inline)
+	want := Schema{{Name: "PORT", Type: TypePort}, {Name: "HOST", Type: TypeHostname}, {Name: "TZ", Type: TypeString}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,220 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Type is the kind of value a variable holds
+type Type string
+
+const (
+	TypeString   Type = "string"
+	TypeInt      Type = "int"
+	TypeFloat    Type = "float"
+	TypeBool     Type = "bool"
+	TypeDuration Type = "duration"
+	TypeURL      Type = "url"
+	TypeEmail    Type = "email"
+	TypeHostname Type = "hostname"
+	TypeIP       Type = "ip"
+	TypeCIDR     Type = "cidr"
+	TypePort     Type = "port"
+	TypeJSON     Type = "json"
+	TypeBase64   Type = "base64"
+	TypeEnum     Type = "enum"
+	TypeRegex    Type = "regex"
+)
+
+// Types lists every supported type
+var Types = []Type{
+	TypeString, TypeInt, TypeFloat, TypeBool, TypeDuration, TypeURL, TypeEmail,
+	TypeHostname, TypeIP, TypeCIDR, TypePort, TypeJSON, TypeBase64, TypeEnum, TypeRegex,
+}
+
+// Field describes the values one variable may take
+type Field struct {
+	Name        string   `yaml:"-"`
+	Type        Type     `yaml:"type"`
+	Description string   `yaml:"description,omitempty"`
+	Required    bool     `yaml:"required,omitempty"`
+	Default     string   `yaml:"default,omitempty"` // value the application uses when unset, for docs
+	Min         string   `yaml:"min,omitempty"`     // number, duration or string length, depending on type
+	Max         string   `yaml:"max,omitempty"`     // number, duration or string length, depending on type
+	Values      []string `yaml:"values,omitempty"`  // allowed values of an enum
+	Pattern     string   `yaml:"pattern,omitempty"` // regular expression the whole value must match
+	Schemes     []string `yaml:"schemes,omitempty"` // allowed URL schemes, e.g. https
+
+	re *regexp.Regexp // Pattern anchored to the whole value, set by Check
+}
+
+// Schema is the ordered list of fields from the schema: section of
+// .ecolint.yaml, keyed by variable name:
+//
+//	schema:
+//	  PORT:
+//	    type: port
+//	  LOG_LEVEL:
+//	    type: enum
+//	    values: [debug, info, warn, error]
+//	  TIMEOUT:
+//	    type: duration
+//	    max: 5m
+type Schema []Field
+
+// UnmarshalYAML keeps fields in the order they are written
+func (s *Schema) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var items yaml.MapSlice
+	if err := unmarshal(&items); err != nil {
+		return err
+	}
+
+	*s = nil
+	for _, item := range items {
+		data, err := yaml.Marshal(item.Value)
+		if err != nil {
+			return err
+		}
+		var field Field
+		if err := yaml.Unmarshal(data, &field); err != nil {
+			return fmt.Errorf("schema for %v: %w", item.Key, err)
+		}
+		field.Name = fmt.Sprint(item.Key)
+		*s = append(*s, field)
+	}
+	return nil
+}
+
+// Field returns the field for a variable, if the schema has one
+func (s Schema) Field(name string) (Field, bool) {
+	for _, f := range s {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// Merge returns the fields of s, replaced or followed by those of override
+func (s Schema) Merge(override Schema) Schema {
+	merged := append(Schema{}, s...)
+	for _, f := range override {
+		replaced := false
+		for i := range merged {
+			if merged[i].Name == f.Name {
+				merged[i], replaced = f, true
+			}
+		}
+		if !replaced {
+			merged = append(merged, f)
+		}
+	}
+	return merged
+}
+
+// Required returns the names of fields marked required
+func (s Schema) Required() []string {
+	var names []string
+	for _, f := range s {
+		if f.Required {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+// Check reports mistakes in the schema itself, such as unknown types or
+// constraints that don't parse, and compiles patterns for Validate
+func (s Schema) Check() error {
+	for i := range s {
+		if err := s[i].check(); err != nil {
+			return fmt.Errorf("schema for %s: %w", s[i].Name, err)
+		}
+	}
+	return nil
+}
+
+func (f *Field) check() error {
+	if f.Type == "" {
+		return fmt.Errorf("missing type")
+	}
+	known := false
+	for _, t := range Types {
+		known = known || t == f.Type
+	}
+	if !known {
+		return fmt.Errorf("unknown type %q", f.Type)
+	}
+
+	switch f.Type {
+	case TypeEnum:
+		if len(f.Values) == 0 {
+			return fmt.Errorf("enum needs values")
+		}
+	case TypeRegex:
+		if f.Pattern == "" {
+			return fmt.Errorf("regex needs a pattern")
+		}
+	}
+
+	if f.Pattern != "" {
+		re, err := f.compilePattern()
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+		f.re = re
+	}
+	for _, bound := range []string{f.Min, f.Max} {
+		if bound == "" {
+			continue
+		}
+		if _, err := f.parseBound(bound); err != nil {
+			return err
+		}
+	}
+	if f.Default != "" {
+		if err := f.Validate(f.Default); err != nil {
+			return fmt.Errorf("invalid default: %s", err.Message)
+		}
+	}
+	return nil
+}
+
+// compilePattern compiles Pattern to match the whole value. The pattern is
+// checked on its own first, since wrapping it can make a pattern such as
+// "a)(b" valid.
+func (f Field) compilePattern() (*regexp.Regexp, error) {
+	if _, err := regexp.Compile(f.Pattern); err != nil {
+		return nil, err
+	}
+	return regexp.Compile(`^(?:` + f.Pattern + `)$`)
+}
+
+// parseBound converts min or max to a number: the value itself for numeric
+// types, nanoseconds for durations and a length for everything else
+func (f Field) parseBound(bound string) (float64, error) {
+	switch f.Type {
+	case TypeInt, TypeFloat, TypePort:
+		n, err := strconv.ParseFloat(bound, 64)
+		if err != nil {
+			return 0, fmt.Errorf("min and max of %s must be numbers, got %q", f.Type, bound)
+		}
+		return n, nil
+	case TypeDuration:
+		d, err := time.ParseDuration(bound)
+		if err != nil {
+			return 0, fmt.Errorf("min and max of duration must be durations such as 30s, got %q", bound)
+		}
+		return float64(d), nil
+	default:
+		n, err := strconv.Atoi(bound)
+		if err != nil {
+			return 0, fmt.Errorf("min and max of %s are lengths and must be whole numbers, got %q", f.Type, bound)
+		}
+		return float64(n), nil
+	}
+}
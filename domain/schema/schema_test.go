@@ -0,0 +1,154 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		field Field
+		value string
+		valid bool
+	}{
+		{"int", Field{Type: TypeInt}, "42", true},
+		{"int with letters", Field{Type: TypeInt}, "80a", false},
+		{"int below min", Field{Type: TypeInt, Min: "1"}, "0", false},
+		{"float above max", Field{Type: TypeFloat, Max: "1"}, "1.5", false},
+		{"float in range", Field{Type: TypeFloat, Min: "0", Max: "1"}, "0.25", true},
+		{"bool", Field{Type: TypeBool}, "Yes", true},
+		{"bool word", Field{Type: TypeBool}, "enabled", false},
+		{"duration", Field{Type: TypeDuration}, "1m30s", true},
+		{"duration without unit", Field{Type: TypeDuration}, "30", false},
+		{"duration above max", Field{Type: TypeDuration, Max: "5m"}, "10m", false},
+		{"url", Field{Type: TypeURL}, "https://api.example.com/v1", true},
+		{"url without scheme", Field{Type: TypeURL}, "api.example.com", false},
+		{"url scheme allowed", Field{Type: TypeURL, Schemes: []string{"postgres"}}, "postgres://db:5432/app", true},
+		{"url scheme not allowed", Field{Type: TypeURL, Schemes: []string{"https"}}, "http://example.com", false},
+		{"email", Field{Type: TypeEmail}, "ops@example.com", true},
+		{"email with name", Field{Type: TypeEmail}, "Ops <ops@example.com>", false},
+		{"hostname", Field{Type: TypeHostname}, "db.internal", true},
+		{"hostname with scheme", Field{Type: TypeHostname}, "http://db", false},
+		{"ipv4", Field{Type: TypeIP}, "10.0.0.1", true},
+		{"ipv6", Field{Type: TypeIP}, "::1", true},
+		{"ip out of range", Field{Type: TypeIP}, "10.0.0.256", false},
+		{"cidr", Field{Type: TypeCIDR}, "10.0.0.0/8", true},
+		{"cidr without mask", Field{Type: TypeCIDR}, "10.0.0.0", false},
+		{"port", Field{Type: TypePort}, "8080", true},
+		{"port with letters", Field{Type: TypePort}, "80a", false},
+		{"port out of range", Field{Type: TypePort}, "70000", false},
+		{"port below min", Field{Type: TypePort, Min: "1024"}, "80", false},
+		{"json", Field{Type: TypeJSON}, `{"a": [1, 2]}`, true},
+		{"json single quotes", Field{Type: TypeJSON}, `{'a': 1}`, false},
+		{"base64", Field{Type: TypeBase64}, "aGVsbG8=", true},
+		{"base64 url safe unpadded", Field{Type: TypeBase64}, "aGVsbG8_-w", true},
+		{"base64 invalid", Field{Type: TypeBase64}, "not base64!", false},
+		{"enum", Field{Type: TypeEnum, Values: []string{"debug", "info"}}, "info", true},
+		{"enum unknown", Field{Type: TypeEnum, Values: []string{"debug", "info"}}, "verbose", false},
+		{"regex", Field{Type: TypeRegex, Pattern: `v\d+`}, "v2", true},
+		{"regex partial match", Field{Type: TypeRegex, Pattern: `v\d+`}, "v2-beta", false},
+		{"string too short", Field{Type: TypeString, Min: "32"}, "short", false},
+		{"string with pattern", Field{Type: TypeString, Pattern: `[a-z]+`}, "abc", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.field.Validate(tt.value)
+			if (err == nil) != tt.valid {
+				t.Errorf("Validate(%q) = %v, want valid %v", tt.value, err, tt.valid)
+			}
+			if err != nil && (err.Message == "" || err.Hint == "") {
+				t.Errorf("Validate(%q) returned an error without a message or hint: %+v", tt.value, err)
+			}
+		})
+	}
+}
+
+func TestSchemaYAML(t *testing.T) {
+	src := `
+schema:
+  PORT:
+    type: port
+    min: 1024
+  LOG_LEVEL:
+    type: enum
+    values: [debug, info]
+  TIMEOUT:
+    type: duration
+    max: 5m
+    required: true
+`
+	var cfg struct {
+		Schema Schema `yaml:"schema"`
+	}
+	if err := yaml.Unmarshal([]byte(src), &cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, f := range cfg.Schema {
+		names = append(names, f.Name)
+	}
+	if got := strings.Join(names, ","); got != "PORT,LOG_LEVEL,TIMEOUT" {
+		t.Errorf("fields in order %s, want PORT,LOG_LEVEL,TIMEOUT", got)
+	}
+	if port, _ := cfg.Schema.Field("PORT"); port.Min != "1024" {
+		t.Errorf("PORT min = %q, want 1024", port.Min)
+	}
+	if err := cfg.Schema.Check(); err != nil {
+		t.Errorf("Check() = %v", err)
+	}
+	if req := cfg.Schema.Required(); len(req) != 1 || req[0] != "TIMEOUT" {
+		t.Errorf("Required() = %v, want [TIMEOUT]", req)
+	}
+}
+
+func TestSchemaCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema Schema
+		want   string
+	}{
+		{"unknown type", Schema{{Name: "A", Type: "number"}}, `unknown type "number"`},
+		{"missing type", Schema{{Name: "A"}}, "missing type"},
+		{"enum without values", Schema{{Name: "A", Type: TypeEnum}}, "enum needs values"},
+		{"regex without pattern", Schema{{Name: "A", Type: TypeRegex}}, "regex needs a pattern"},
+		{"bad pattern", Schema{{Name: "A", Type: TypeString, Pattern: "("}}, "invalid pattern"},
+		{"pattern only valid when anchored", Schema{{Name: "A", Type: TypeString, Pattern: "a)(b"}}, "invalid pattern"},
+		{"bad duration bound", Schema{{Name: "A", Type: TypeDuration, Max: "5"}}, "must be durations"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schema.Check()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Check() = %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckCompilesPattern(t *testing.T) {
+	s := Schema{{Name: "REGION", Type: TypeRegex, Pattern: `[a-z]+-[0-9]`}}
+	if err := s.Check(); err != nil {
+		t.Fatal(err)
+	}
+	if s[0].re == nil {
+		t.Fatal("Check() did not compile the pattern")
+	}
+	if err := s[0].Validate("eu-1"); err != nil {
+		t.Errorf("Validate(eu-1) = %v, want valid", err)
+	}
+	if err := s[0].Validate("eu-1x"); err == nil {
+		t.Error("Validate(eu-1x) = nil, want the pattern to match the whole value")
+	}
+
+	// A field that skipped Check reports a bad pattern instead of panicking
+	unchecked := Field{Name: "A", Type: TypeString, Pattern: "a)(b"}
+	if err := unchecked.Validate("ab"); err == nil || !strings.Contains(err.Message, "invalid") {
+		t.Errorf("Validate() = %v, want an invalid pattern error", err)
+	}
+}
@@ -0,0 +1,220 @@
+package schema
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValueError explains why a value doesn't match its field
+type ValueError struct {
+	Message string // what is wrong, e.g. "80a" is not a whole number
+	Hint    string // what would be accepted, e.g. a port between 1 and 65535
+}
+
+func (e *ValueError) Error() string {
+	return e.Message
+}
+
+var (
+	hostnamePattern = regexp.MustCompile(`^(?i)[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*\.?$`)
+	boolValues      = map[string]bool{
+		"true": true, "false": true, "1": true, "0": true,
+		"yes": true, "no": true, "on": true, "off": true,
+	}
+)
+
+// Validate checks a value against the field's type and constraints. The
+// schema should have passed Check, which compiles the pattern once.
+func (f Field) Validate(value string) *ValueError {
+	if err := f.validateType(value); err != nil {
+		return err
+	}
+	if err := f.validateRange(value); err != nil {
+		return err
+	}
+	if f.Pattern != "" {
+		re := f.re
+		if re == nil {
+			var err error
+			if re, err = f.compilePattern(); err != nil {
+				return &ValueError{
+					Message: fmt.Sprintf("the schema pattern %s is invalid: %v", f.Pattern, err),
+					Hint:    "Fix the pattern in the schema",
+				}
+			}
+		}
+		if !re.MatchString(value) {
+			return &ValueError{
+				Message: fmt.Sprintf("%s does not match the pattern %s", f.quote(value), f.Pattern),
+				Hint:    "Expected a value matching " + f.Pattern,
+			}
+		}
+	}
+	return nil
+}
+
+func (f Field) validateType(value string) *ValueError {
+	invalid := func(what, hint string) *ValueError {
+		return &ValueError{Message: fmt.Sprintf("%s is not %s", f.quote(value), what), Hint: hint}
+	}
+
+	switch f.Type {
+	case TypeInt:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return invalid("a whole number", "Expected an integer such as 42")
+		}
+	case TypeFloat:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return invalid("a number", "Expected a number such as 0.5")
+		}
+	case TypePort:
+		port, err := strconv.Atoi(value)
+		if err != nil || port < 1 || port > 65535 {
+			return invalid("a valid port", "Expected a port between 1 and 65535")
+		}
+	case TypeBool:
+		if !boolValues[strings.ToLower(value)] {
+			return invalid("a boolean", "Expected true/false, 1/0, yes/no or on/off")
+		}
+	case TypeDuration:
+		if _, err := time.ParseDuration(value); err != nil {
+			if _, numErr := strconv.ParseFloat(value, 64); numErr == nil {
+				return invalid("a duration (missing unit)", fmt.Sprintf("Add a unit, e.g. %ss or %sms", value, value))
+			}
+			return invalid("a duration", "Expected a duration such as 30s, 500ms or 1h30m")
+		}
+	case TypeURL:
+		u, err := url.Parse(value)
+		if err != nil || u.Scheme == "" || u.Host == "" && u.Opaque == "" {
+			return invalid("an absolute URL", "Expected a URL such as https://example.com")
+		}
+		if len(f.Schemes) > 0 && !contains(f.Schemes, strings.ToLower(u.Scheme)) {
+			return &ValueError{
+				Message: fmt.Sprintf("URL scheme %q is not allowed", u.Scheme),
+				Hint:    "Expected one of: " + strings.Join(f.Schemes, ", "),
+			}
+		}
+	case TypeEmail:
+		addr, err := mail.ParseAddress(value)
+		if err != nil || addr.Address != value {
+			return invalid("an email address", "Expected an address such as ops@example.com")
+		}
+	case TypeHostname:
+		if len(value) > 253 || !hostnamePattern.MatchString(value) {
+			return invalid("a hostname", "Expected a hostname such as db.internal")
+		}
+	case TypeIP:
+		if net.ParseIP(value) == nil {
+			return invalid("an IP address", "Expected an IPv4 or IPv6 address such as 10.0.0.1")
+		}
+	case TypeCIDR:
+		if _, _, err := net.ParseCIDR(value); err != nil {
+			return invalid("a CIDR range", "Expected a range such as 10.0.0.0/8")
+		}
+	case TypeJSON:
+		if !json.Valid([]byte(value)) {
+			return invalid("valid JSON", "Check quoting; single quotes around the whole value keep inner double quotes")
+		}
+	case TypeBase64:
+		if !isBase64(value) {
+			return invalid("valid base64", "Expected standard or URL-safe base64, with or without padding")
+		}
+	case TypeEnum:
+		if !contains(f.Values, value) {
+			return &ValueError{
+				Message: fmt.Sprintf("%q is not an allowed value", value),
+				Hint:    "Expected one of: " + strings.Join(f.Values, ", "),
+			}
+		}
+	}
+	return nil
+}
+
+// validateRange checks min and max: numbers for numeric types, durations
+// for durations and lengths for everything else
+func (f Field) validateRange(value string) *ValueError {
+	if f.Min == "" && f.Max == "" {
+		return nil
+	}
+
+	var n float64
+	subject := f.quote(value)
+	switch f.Type {
+	case TypeInt, TypeFloat, TypePort:
+		n, _ = strconv.ParseFloat(value, 64)
+	case TypeDuration:
+		d, _ := time.ParseDuration(value)
+		n = float64(d)
+	default:
+		n = float64(len(value))
+		subject = fmt.Sprintf("length %d", len(value))
+	}
+
+	if f.Min != "" {
+		if min, _ := f.parseBound(f.Min); n < min {
+			return &ValueError{
+				Message: fmt.Sprintf("%s is below the minimum %s", subject, f.Min),
+				Hint:    describeRange(f),
+			}
+		}
+	}
+	if f.Max != "" {
+		if max, _ := f.parseBound(f.Max); n > max {
+			return &ValueError{
+				Message: fmt.Sprintf("%s is above the maximum %s", subject, f.Max),
+				Hint:    describeRange(f),
+			}
+		}
+	}
+	return nil
+}
+
+// quote shows a value in a message. Types that may hold credentials, such
+// as URLs with passwords or base64 keys, aren't shown since messages are
+// not redacted.
+func (f Field) quote(value string) string {
+	switch f.Type {
+	case TypeInt, TypeFloat, TypePort, TypeBool, TypeDuration, TypeEnum,
+		TypeHostname, TypeIP, TypeCIDR, TypeEmail:
+		return fmt.Sprintf("%q", value)
+	default:
+		return "value"
+	}
+}
+
+func describeRange(f Field) string {
+	switch {
+	case f.Min != "" && f.Max != "":
+		return fmt.Sprintf("Expected between %s and %s", f.Min, f.Max)
+	case f.Min != "":
+		return "Expected at least " + f.Min
+	default:
+		return "Expected at most " + f.Max
+	}
+}
+
+func isBase64(value string) bool {
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+		if _, err := enc.DecodeString(value); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tahcohcat/ecolint/rules"
+	"gopkg.in/yaml.v2"
+)
+
+// AddAllowEntry adds an entry to security.allow in the config file at path,
+// creating the file or section if needed. The file is edited as text so
+// comments and formatting are kept.
+func AddAllowEntry(path string, entry rules.AllowEntry) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	updated, err := insertAllowEntry(string(data), entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(updated), 0644)
+}
+
+// insertAllowEntry returns content with entry appended to security.allow
+func insertAllowEntry(content string, entry rules.AllowEntry) (string, error) {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	if content == "" {
+		lines = nil
+	}
+
+	security := findKey(lines, 0, len(lines), "security", 0)
+	var out []string
+	switch {
+	case security < 0:
+		out = append(lines, "", "security:", "  allow:")
+		out = append(out, entryLines(entry, "    ")...)
+	case strings.TrimSpace(strings.SplitN(lines[security], ":", 2)[1]) != "":
+		return "", fmt.Errorf("security: is written inline; add the allow entry by hand")
+	default:
+		end := blockEnd(lines, security, 0)
+		childIndent := indentOf(lines, security+1, end, 2)
+		allow := findKey(lines, security+1, end, "allow", childIndent)
+
+		if allow < 0 {
+			out = append(out, lines[:security+1]...)
+			out = append(out, strings.Repeat(" ", childIndent)+"allow:")
+			out = append(out, entryLines(entry, strings.Repeat(" ", childIndent+2))...)
+			out = append(out, lines[security+1:]...)
+			break
+		}
+		if rest := strings.TrimSpace(strings.SplitN(lines[allow], ":", 2)[1]); rest != "" && rest != "[]" {
+			return "", fmt.Errorf("security.allow is written inline; add the entry by hand")
+		}
+
+		// List items may sit at the same indent as "allow:" in YAML
+		allowEnd := allow + 1
+		for allowEnd < end {
+			trimmed := strings.TrimSpace(lines[allowEnd])
+			indent := len(lines[allowEnd]) - len(strings.TrimLeft(lines[allowEnd], " "))
+			if trimmed != "" && !strings.HasPrefix(trimmed, "#") &&
+				indent <= childIndent && !(indent == childIndent && strings.HasPrefix(trimmed, "-")) {
+				break
+			}
+			allowEnd++
+		}
+		// Keep trailing blank lines and comments after the list
+		for allowEnd > allow+1 && isBlankOrComment(lines[allowEnd-1]) {
+			allowEnd--
+		}
+		itemIndent := indentOf(lines, allow+1, allowEnd, childIndent+2)
+
+		lines[allow] = strings.Repeat(" ", childIndent) + "allow:"
+		out = append(out, lines[:allowEnd]...)
+		out = append(out, entryLines(entry, strings.Repeat(" ", itemIndent))...)
+		out = append(out, lines[allowEnd:]...)
+	}
+
+	updated := strings.Join(out, "\n") + "\n"
+	updated = strings.TrimLeft(updated, "\n")
+
+	// Make sure the result still parses and holds the entry
+	var check Config
+	if err := yaml.Unmarshal([]byte(updated), &check); err != nil {
+		return "", fmt.Errorf("could not add the entry to the config: %w", err)
+	}
+	for _, e := range check.Security.Allow {
+		if e.Key == entry.Key && e.SHA256 == entry.SHA256 && e.Pattern == entry.Pattern {
+			return updated, nil
+		}
+	}
+	return "", fmt.Errorf("could not add the entry to the config; add it under security.allow by hand")
+}
+
+func entryLines(entry rules.AllowEntry, indent string) []string {
+	var fields []string
+	if entry.Key != "" {
+		fields = append(fields, "key: "+entry.Key)
+	}
+	if entry.SHA256 != "" {
+		fields = append(fields, "sha256: "+entry.SHA256)
+	}
+	if entry.Pattern != "" {
+		fields = append(fields, fmt.Sprintf("pattern: %q", entry.Pattern))
+	}
+	if entry.Reason != "" {
+		fields = append(fields, fmt.Sprintf("reason: %q", entry.Reason))
+	}
+
+	lines := make([]string, len(fields))
+	for i, field := range fields {
+		prefix := indent + "  "
+		if i == 0 {
+			prefix = indent + "- "
+		}
+		lines[i] = prefix + field
+	}
+	return lines
+}
+
+// findKey returns the line in [from, to) that starts the mapping key at
+// the given indent, or -1
+func findKey(lines []string, from, to int, key string, indent int) int {
+	for i := from; i < to; i++ {
+		if strings.HasPrefix(lines[i], strings.Repeat(" ", indent)+key+":") {
+			return i
+		}
+	}
+	return -1
+}
+
+// blockEnd returns the line after the block started by line start
+func blockEnd(lines []string, start, indent int) int {
+	for i := start + 1; i < len(lines); i++ {
+		if isBlankOrComment(lines[i]) {
+			continue
+		}
+		if len(lines[i])-len(strings.TrimLeft(lines[i], " ")) <= indent {
+			return i
+		}
+	}
+	return len(lines)
+}
+
+// indentOf returns the indent of the first content line in [from, to)
+func indentOf(lines []string, from, to, fallback int) int {
+	for i := from; i < to; i++ {
+		if !isBlankOrComment(lines[i]) {
+			return len(lines[i]) - len(strings.TrimLeft(lines[i], " "))
+		}
+	}
+	return fallback
+}
+
+func isBlankOrComment(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || strings.HasPrefix(trimmed, "#")
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/tahcohcat/ecolint/rules"
+)
+
+func TestInsertAllowEntry(t *testing.T) {
+	entry := rules.AllowEntry{Key: "PK", SHA256: "ab12"}
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "new file",
+			expected: "security:\n  allow:\n    - key: PK\n      sha256: ab12\n",
+		},
+		{
+			name:     "no security section",
+			content:  "# config\nrules:\n  security: true\n",
+			expected: "# config\nrules:\n  security: true\n\nsecurity:\n  allow:\n    - key: PK\n      sha256: ab12\n",
+		},
+		{
+			name:     "security without allow",
+			content:  "security:\n    min_length: 20\noutput:\n    format: json\n",
+			expected: "security:\n    allow:\n      - key: PK\n        sha256: ab12\n    min_length: 20\noutput:\n    format: json\n",
+		},
+		{
+			name:     "appended to allow, keeping comments",
+			content:  "security:\n  allow:\n    - key: OTHER # public\n\n  # tuning\n  min_length: 20\n",
+			expected: "security:\n  allow:\n    - key: OTHER # public\n    - key: PK\n      sha256: ab12\n\n  # tuning\n  min_length: 20\n",
+		},
+		{
+			name:     "list items at the same indent as allow",
+			content:  "security:\n  allow:\n  - key: OTHER\n",
+			expected: "security:\n  allow:\n  - key: OTHER\n  - key: PK\n    sha256: ab12\n",
+		},
+		{
+			name:     "empty inline list",
+			content:  "security:\n  allow: []\n",
+			expected: "security:\n  allow:\n    - key: PK\n      sha256: ab12\n",
+		},
+		{
+			name:    "inline security section",
+			content: "security: {min_length: 20}\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := insertAllowEntry(tt.content, entry)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("insertAllowEntry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("insertAllowEntry() =\n%s\nwant\n%s", got, tt.expected)
+			}
+		})
+	}
+}
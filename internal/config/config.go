@@ -5,13 +5,24 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/tahcohcat/ecolint/domain/schema"
+	"github.com/tahcohcat/ecolint/rules"
 	"gopkg.in/yaml.v2"
 )
 
 type Config struct {
-	RequiredVars []string `yaml:"required_vars"`
-	Rules        Rules    `yaml:"rules"`
-	Output       Output   `yaml:"output"`
+	RequiredVars []string      `yaml:"required_vars"`
+	AllowUnused  []string      `yaml:"allow_unused"` // added to rules.DefaultUnusedAllow
+	Rules        Rules         `yaml:"rules"`
+	Output       Output        `yaml:"output"`
+	Scan         Scan          `yaml:"scan"`
+	Consistency  Consistency   `yaml:"consistency"`
+	Example      Example       `yaml:"example"`
+	Security     Security      `yaml:"security"`
+	Schema       schema.Schema `yaml:"schema"`      // value types and constraints, checked by rules.schema
+	SchemaFile   string        `yaml:"schema_file"` // JSON Schema to import, relative to this file; schema entries override its properties
+
+	Path string `yaml:"-"` // the file the config was loaded from, "" for defaults
 }
 
 type Rules struct {
@@ -21,11 +32,70 @@ type Rules struct {
 	Convention  bool `yaml:"convention"`
 	Syntax      bool `yaml:"syntax"`
 	EmptyValues bool `yaml:"empty_values"`
+	Deployment  bool `yaml:"deployment"`  // compare with Kubernetes, docker-compose and Helm manifests
+	Unused      bool `yaml:"unused"`      // report variables no source file reads
+	Schema      bool `yaml:"schema"`      // check values against the schema section
+	Consistency bool `yaml:"consistency"` // compare variants such as .env.staging and .env.production
+	Example     bool `yaml:"example"`     // compare each file with the .env.example next to it
 }
 
 type Output struct {
-	Format string `yaml:"format"`
-	Color  bool   `yaml:"color"`
+	Format   string `yaml:"format"`
+	Color    bool   `yaml:"color"`
+	Redact   string `yaml:"redact"`
+	Template string `yaml:"template"`
+}
+
+// Consistency configures the consistency rule. Files matching allow_differ
+// are also exempt from the example rule.
+type Consistency struct {
+	AllowDiffer []string `yaml:"allow_differ"` // file name patterns, added to rules.DefaultAllowDiffer
+}
+
+// Security tunes the security rule's secret detection
+type Security struct {
+	MinConfidence string  `yaml:"min_confidence"` // low, medium or high
+	MinLength     int     `yaml:"min_length"`     // shorter values are only reported on key-name hints
+	Entropy       Entropy `yaml:"entropy"`
+
+	// Values that are never reported, added to rules.DefaultPlaceholders.
+	// Whole values, ignoring case; * and ? wildcards are allowed.
+	Placeholders []string `yaml:"placeholders"`
+
+	// Variables that are never reported, e.g. publishable keys. Add entries
+	// with `ecolint secrets allow KEY`.
+	Allow []rules.AllowEntry `yaml:"allow"`
+
+	// Credential formats added to rules.DefaultDetectors; an entry with the
+	// same id as a built-in detector replaces it
+	Detectors []rules.Detector `yaml:"detectors"`
+}
+
+// Entropy holds the bits per character a value needs to look random, by
+// the character set it uses
+type Entropy struct {
+	Hex          float64 `yaml:"hex"`
+	Alphanumeric float64 `yaml:"alphanumeric"`
+	Base64       float64 `yaml:"base64"`
+}
+
+// Example configures the example rule and `ecolint sync-example`
+type Example struct {
+	Template string `yaml:"template"` // template file name, looked up next to each env file
+}
+
+// Scan configures project scanning for `ecolint scan` and --auto-discover
+type Scan struct {
+	MinConfidence     float64  `yaml:"min_confidence"`
+	MinUsages         int      `yaml:"min_usages"`
+	Include           []string `yaml:"include"`            // globs; when set, only matching files are scanned
+	Exclude           []string `yaml:"exclude"`            // gitignore-style patterns, added to the built-in excludes
+	ExcludePaths      []string `yaml:"exclude_paths"`      // older name for exclude
+	IncludeExtensions []string `yaml:"include_extensions"` // added to the built-in extensions
+	Concurrency       int      `yaml:"concurrency"`        // files scanned in parallel, 0 for one per CPU
+	SkipBinary        bool     `yaml:"skip_binary"`        // skip files containing NUL bytes
+	SkipMinified      bool     `yaml:"skip_minified"`      // skip *.min.* files and files with very long lines
+	MaxFileSize       int64    `yaml:"max_file_size"`      // in bytes; larger files are skipped, 0 for no limit
 }
 
 func Load(configFile string) Config {
@@ -37,11 +107,31 @@ func Load(configFile string) Config {
 			Missing:     true,
 			Syntax:      true,
 			EmptyValues: true,
+			Schema:      true,
 		},
 		Output: Output{
 			Format: "pretty",
 			Color:  true,
 		},
+		Example: Example{
+			Template: ".env.example",
+		},
+		Security: Security{
+			MinConfidence: "medium",
+			MinLength:     16,
+			Entropy: Entropy{
+				Hex:          3.0,
+				Alphanumeric: 3.5,
+				Base64:       4.0,
+			},
+		},
+		Scan: Scan{
+			MinConfidence: 0.7,
+			MinUsages:     1,
+			SkipBinary:    true,
+			SkipMinified:  true,
+			MaxFileSize:   1 << 20,
+		},
 	}
 
 	// Try to find config file
@@ -68,11 +158,22 @@ func Load(configFile string) Config {
 	// Load config file
 	if data, err := ioutil.ReadFile(configFile); err == nil {
 		yaml.Unmarshal(data, &cfg)
+		cfg.Path = configFile
 	}
 
 	return cfg
 }
 
+// Resolve returns a path from the config file relative to the file's
+// directory, so it works whatever directory ecolint runs from. Absolute
+// paths, and paths when no config file was loaded, are returned as is.
+func (c Config) Resolve(path string) string {
+	if path == "" || c.Path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(filepath.Dir(c.Path), path)
+}
+
 func CreateSampleConfig(path string) error {
 	sampleConfig := `# ecolint configuration file
 # 🌱 cultivating clean environments
@@ -89,11 +190,74 @@ rules:
   missing: true        # Check for missing required variables
   syntax: true         # Validate .env file syntax
   empty_values: true   # Warn about empty variable values
+  deployment: false    # Compare with variables set by Kubernetes, docker-compose and Helm manifests
+  unused: false        # Report variables that no source file reads
+  schema: true         # Check values against the schema section below
+  consistency: false   # Report keys missing from some variants, e.g. .env.staging vs .env.production
+  example: false       # Report keys missing from .env.example, or set there but not in the file
+
+# Files that may differ from their variants and from .env.example
+# (.env.local and .env.*.local always may)
+# consistency:
+#   allow_differ:
+#     - ".env.test"
+
+# Secret detection. Values are scored by entropy for their character set and
+# by hints in the variable name; findings have low, medium or high confidence.
+# security:
+#   min_confidence: medium   # low also reports random hex, such as commit SHAs
+#   min_length: 16
+#   entropy:                 # bits per character
+#     hex: 3.0
+#     alphanumeric: 3.5
+#     base64: 4.0
+#   allow:                   # values that are public on purpose; add with 'ecolint secrets allow KEY'
+#     - key: STRIPE_PUBLISHABLE_KEY
+#     - sha256: 9f86d081884c7d659a2f98ea0a08d1ba...   # hash of the value, never the value
+#     - pattern: '^pk_(live|test)_'
+#   placeholders:            # whole values that are never reported, e.g. shared dev defaults
+#     - "dev-only-*"
+#   detectors:               # credential formats, added to the built-in ones
+#     - id: acme-api-key
+#       provider: Acme
+#       name: API key
+#       pattern: '^acme_[a-z0-9]{32}$'
+#       rotate: Regenerate it in the Acme dashboard under Settings → API
+
+# The template the example rule and 'ecolint sync-example' use
+# example:
+#   template: ".env.example"
+
+# A JSON Schema describing the environment, e.g. published by a platform team
+# schema_file: "env.schema.json"
+
+# Value types and constraints. Types: string, int, float, bool, duration, url,
+# email, hostname, ip, cidr, port, json, base64, enum, regex
+schema:
+  PORT:
+    type: port
+  LOG_LEVEL:
+    type: enum
+    values: [debug, info, warn, error]
+  DATABASE_URL:
+    type: url
+    schemes: [postgres, postgresql]
+    required: true
+  # REQUEST_TIMEOUT:
+  #   type: duration
+  #   min: 1s
+  #   max: 5m
+
+# Variables read by tools outside the project, never reported as unused
+# allow_unused:
+#   - SENTRY_*
 
 # Output configuration  
 output:
-  format: "pretty"     # Output format: pretty, json, github
+  format: "pretty"     # Output format: pretty, json, github, markdown, html, template
   color: true          # Enable colored output
+  # template: "report.tmpl"  # Go text/template used by the template format
+  # redact: "full"     # Mask secret values: none, partial, full (every format but pretty defaults to full)
 `
 
 	dir := filepath.Dir(path)
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		path     string
+		expected string
+	}{
+		{name: "next to the config", config: ".ecolint.yaml", path: "env.schema.json", expected: "env.schema.json"},
+		{name: "config in a subdirectory", config: filepath.Join("config", ".ecolint.yaml"), path: "env.schema.json", expected: filepath.Join("config", "env.schema.json")},
+		{name: "relative to a parent", config: filepath.Join("config", ".ecolint.yaml"), path: filepath.Join("..", "schemas", "env.json"), expected: filepath.Join("schemas", "env.json")},
+		{name: "absolute path", config: filepath.Join("config", ".ecolint.yaml"), path: "/etc/env.schema.json", expected: "/etc/env.schema.json"},
+		{name: "no config file", path: "env.schema.json", expected: "env.schema.json"},
+		{name: "empty path", config: ".ecolint.yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Config{Path: tt.config}.Resolve(tt.path)
+			if got != tt.expected {
+				t.Errorf("Resolve(%q) = %q, want %q", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
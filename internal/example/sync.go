@@ -0,0 +1,124 @@
+// Package example keeps a template such as .env.example in step with the
+// env files it documents.
+package example
+
+import (
+	"strings"
+)
+
+// Placeholder returns the value to write for a key added to the template,
+// and a comment to put above it ("" for none)
+type Placeholder func(key string) (value, comment string)
+
+// Result is an updated template
+type Result struct {
+	Lines   []string
+	Added   []string // keys appended, in order
+	Removed []string // keys no longer set by any env file
+}
+
+// Changed reports whether the template needs rewriting
+func (r Result) Changed() bool {
+	return len(r.Added) > 0 || len(r.Removed) > 0
+}
+
+// Sync updates template lines so they list exactly keys. Lines for keys
+// that stay are kept as they are, with their comments and order. Lines for
+// keys that are gone are dropped with the comment directly above them,
+// unless that comment also heads the next key. New keys are appended in the
+// order given, using placeholder for their value and comment.
+func Sync(lines []string, keys []string, placeholder Placeholder) Result {
+	var result Result
+
+	wanted := make(map[string]bool)
+	for _, key := range keys {
+		wanted[key] = true
+	}
+
+	present := make(map[string]bool)
+	for i := 0; i < len(lines); i++ {
+		key, ok := Key(lines[i])
+		if !ok {
+			result.Lines = append(result.Lines, lines[i])
+			continue
+		}
+		if wanted[key] && !present[key] {
+			present[key] = true
+			result.Lines = append(result.Lines, lines[i])
+			continue
+		}
+
+		// A duplicate or stale key: drop it, and its comment if the
+		// comment isn't shared with the line below
+		if !present[key] {
+			present[key] = true
+			result.Removed = append(result.Removed, key)
+		}
+		if !headsKey(lines, i+1) {
+			result.Lines = dropComment(result.Lines)
+		}
+		// Don't leave two blank lines where the block was
+		if n := len(result.Lines); n > 0 && strings.TrimSpace(result.Lines[n-1]) == "" &&
+			(i+1 == len(lines) || strings.TrimSpace(lines[i+1]) == "") {
+			result.Lines = result.Lines[:n-1]
+		}
+	}
+
+	var added []string
+	for _, key := range keys {
+		if !present[key] {
+			present[key] = true
+			added = append(added, key)
+		}
+	}
+	if len(added) == 0 {
+		return result
+	}
+
+	if n := len(result.Lines); n > 0 && strings.TrimSpace(result.Lines[n-1]) != "" {
+		result.Lines = append(result.Lines, "")
+	}
+	for _, key := range added {
+		value, comment := placeholder(key)
+		if comment != "" {
+			result.Lines = append(result.Lines, "# "+comment)
+		}
+		result.Lines = append(result.Lines, key+"="+value)
+	}
+	result.Added = added
+	return result
+}
+
+// Key returns the key a KEY=VALUE line sets, ignoring an export prefix
+func Key(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return "", false
+	}
+	trimmed = strings.TrimPrefix(trimmed, "export ")
+	key, _, found := strings.Cut(trimmed, "=")
+	key = strings.TrimSpace(key)
+	if !found || key == "" {
+		return "", false
+	}
+	return key, true
+}
+
+// headsKey reports whether line i sets a key, so a comment above the line
+// before it heads a block rather than a single key
+func headsKey(lines []string, i int) bool {
+	if i >= len(lines) {
+		return false
+	}
+	_, ok := Key(lines[i])
+	return ok
+}
+
+// dropComment removes the comment lines at the end of lines
+func dropComment(lines []string) []string {
+	n := len(lines)
+	for n > 0 && strings.HasPrefix(strings.TrimSpace(lines[n-1]), "#") {
+		n--
+	}
+	return lines[:n]
+}
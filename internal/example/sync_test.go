@@ -0,0 +1,87 @@
+package example
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSync(t *testing.T) {
+	placeholder := func(key string) (string, string) {
+		if key == "PORT" {
+			return "8080", "port"
+		}
+		return "", ""
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		keys     []string
+		expected string
+		added    []string
+		removed  []string
+	}{
+		{
+			name:     "up to date",
+			template: "# Database\nDATABASE_URL=\nexport DEBUG=false",
+			keys:     []string{"DEBUG", "DATABASE_URL"},
+			expected: "# Database\nDATABASE_URL=\nexport DEBUG=false",
+		},
+		{
+			name:     "new template",
+			keys:     []string{"DATABASE_URL", "PORT"},
+			expected: "DATABASE_URL=\n# port\nPORT=8080",
+			added:    []string{"DATABASE_URL", "PORT"},
+		},
+		{
+			name:     "appended after existing lines",
+			template: "# Database\nDATABASE_URL=",
+			keys:     []string{"DATABASE_URL", "REDIS_URL"},
+			expected: "# Database\nDATABASE_URL=\n\nREDIS_URL=",
+			added:    []string{"REDIS_URL"},
+		},
+		{
+			name:     "stale key removed with its comment",
+			template: "# Database\nDATABASE_URL=\n\n# No longer used\nLEGACY_TOKEN=\n\n# Cache\nREDIS_URL=",
+			keys:     []string{"DATABASE_URL", "REDIS_URL"},
+			expected: "# Database\nDATABASE_URL=\n\n# Cache\nREDIS_URL=",
+			removed:  []string{"LEGACY_TOKEN"},
+		},
+		{
+			name:     "comment heading a block is kept",
+			template: "# Database\nDB_HOST=\nDB_PORT=",
+			keys:     []string{"DB_PORT"},
+			expected: "# Database\nDB_PORT=",
+			removed:  []string{"DB_HOST"},
+		},
+		{
+			name:     "duplicates removed once",
+			template: "A=\nB=\nA=\nB=",
+			keys:     []string{"A"},
+			expected: "A=",
+			removed:  []string{"B"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lines []string
+			if tt.template != "" {
+				lines = strings.Split(tt.template, "\n")
+			}
+
+			result := Sync(lines, tt.keys, placeholder)
+
+			if got := strings.Join(result.Lines, "\n"); got != tt.expected {
+				t.Errorf("Sync() lines = %q, want %q", got, tt.expected)
+			}
+			if !reflect.DeepEqual(result.Added, tt.added) {
+				t.Errorf("Sync() added = %v, want %v", result.Added, tt.added)
+			}
+			if !reflect.DeepEqual(result.Removed, tt.removed) {
+				t.Errorf("Sync() removed = %v, want %v", result.Removed, tt.removed)
+			}
+		})
+	}
+}
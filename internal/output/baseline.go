@@ -0,0 +1,85 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/tahcohcat/ecolint/domain/issues"
+)
+
+// Baseline is a previous set of issues that new results are compared against
+type Baseline struct {
+	counts map[string]int
+	issues []issues.Issue
+}
+
+// BaselineDiff splits current issues into new and already-known ones
+type BaselineDiff struct {
+	New       []issues.Issue
+	Unchanged []issues.Issue
+	Fixed     []issues.Issue // in the baseline but no longer reported
+}
+
+// LoadBaseline reads a report previously written with --format json
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read baseline: %w", err)
+	}
+
+	var report struct {
+		Issues []issues.Issue `json:"issues"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %w", path, err)
+	}
+
+	return NewBaseline(report.Issues), nil
+}
+
+func NewBaseline(issueList []issues.Issue) *Baseline {
+	b := &Baseline{
+		counts: make(map[string]int),
+		issues: issueList,
+	}
+	for _, issue := range issueList {
+		b.counts[fingerprint(issue)]++
+	}
+	return b
+}
+
+// Diff compares issues against the baseline. Line numbers are ignored so
+// that unrelated edits moving an issue don't make it look new.
+func (b *Baseline) Diff(issueList []issues.Issue) BaselineDiff {
+	var diff BaselineDiff
+
+	remaining := make(map[string]int, len(b.counts))
+	for fp, n := range b.counts {
+		remaining[fp] = n
+	}
+
+	for _, issue := range issueList {
+		fp := fingerprint(issue)
+		if remaining[fp] > 0 {
+			remaining[fp]--
+			diff.Unchanged = append(diff.Unchanged, issue)
+		} else {
+			diff.New = append(diff.New, issue)
+		}
+	}
+
+	for _, issue := range b.issues {
+		fp := fingerprint(issue)
+		if remaining[fp] > 0 {
+			remaining[fp]--
+			diff.Fixed = append(diff.Fixed, issue)
+		}
+	}
+
+	return diff
+}
+
+func fingerprint(issue issues.Issue) string {
+	return issue.File + "\x00" + issue.Name + "\x00" + issue.Key
+}
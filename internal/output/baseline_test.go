@@ -0,0 +1,93 @@
+package output
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/tahcohcat/ecolint/domain/issues"
+)
+
+func TestBaselineDiff(t *testing.T) {
+	issue := func(key string, line int) issues.Issue {
+		return issues.NewIssue("duplicate variable", key, ".env", line, line, nil)
+	}
+
+	tests := []struct {
+		name      string
+		baseline  []issues.Issue
+		current   []issues.Issue
+		new       []string // "key:line"
+		unchanged []string
+		fixed     []string
+	}{
+		{
+			name:      "nothing changed",
+			baseline:  []issues.Issue{issue("PORT", 2), issue("HOST", 4)},
+			current:   []issues.Issue{issue("PORT", 2), issue("HOST", 4)},
+			unchanged: []string{"PORT:2", "HOST:4"},
+		},
+		{
+			name:      "moved lines are unchanged",
+			baseline:  []issues.Issue{issue("PORT", 2)},
+			current:   []issues.Issue{issue("PORT", 12)},
+			unchanged: []string{"PORT:12"},
+		},
+		{
+			name:      "new and fixed",
+			baseline:  []issues.Issue{issue("PORT", 2), issue("HOST", 4)},
+			current:   []issues.Issue{issue("PORT", 2), issue("DEBUG", 6)},
+			new:       []string{"DEBUG:6"},
+			unchanged: []string{"PORT:2"},
+			fixed:     []string{"HOST:4"},
+		},
+		{
+			name:      "extra duplicate is new",
+			baseline:  []issues.Issue{issue("PORT", 2)},
+			current:   []issues.Issue{issue("PORT", 2), issue("PORT", 8)},
+			new:       []string{"PORT:8"},
+			unchanged: []string{"PORT:2"},
+		},
+		{
+			name:      "fewer duplicates are fixed",
+			baseline:  []issues.Issue{issue("PORT", 2), issue("PORT", 8), issue("PORT", 9)},
+			current:   []issues.Issue{issue("PORT", 3)},
+			unchanged: []string{"PORT:3"},
+			fixed:     []string{"PORT:2", "PORT:8"}, // lines are ignored, so the first ones count as fixed
+		},
+		{
+			name:     "other files and rules are different issues",
+			baseline: []issues.Issue{issue("PORT", 2)},
+			current: []issues.Issue{
+				issues.NewIssue("duplicate variable", "PORT", ".env.production", 2, 2, nil),
+				issues.NewIssue("empty value", "PORT", ".env", 2, 2, nil),
+			},
+			new:   []string{"PORT:2", "PORT:2"},
+			fixed: []string{"PORT:2"},
+		},
+		{
+			name:    "empty baseline",
+			current: []issues.Issue{issue("PORT", 2)},
+			new:     []string{"PORT:2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := NewBaseline(tt.baseline).Diff(tt.current)
+			check := func(what string, got []issues.Issue, want []string) {
+				t.Helper()
+				if len(got) != len(want) {
+					t.Fatalf("%s = %v, want %v", what, got, want)
+				}
+				for i, issue := range got {
+					if s := issue.Key + ":" + strconv.Itoa(issue.Line); s != want[i] {
+						t.Errorf("%s[%d] = %s, want %s", what, i, s, want[i])
+					}
+				}
+			}
+			check("New", diff.New, tt.new)
+			check("Unchanged", diff.Unchanged, tt.unchanged)
+			check("Fixed", diff.Fixed, tt.fixed)
+		})
+	}
+}
@@ -3,11 +3,12 @@ package output
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
-	"sort"
 	"strings"
 
 	"github.com/tahcohcat/ecolint/domain/issues"
+	"github.com/tahcohcat/ecolint/rules"
 )
 
 // Colors for pretty output
@@ -24,19 +25,56 @@ const (
 )
 
 type Formatter struct {
-	format string
-	quiet  bool
-	color  bool
+	format       string
+	quiet        bool
+	color        bool
+	out          io.Writer
+	redactor     *Redactor
+	templatePath string
+	baseline     *Baseline
 }
 
 func NewFormatter(format string, quiet bool) *Formatter {
 	return &Formatter{
-		format: format,
-		quiet:  quiet,
-		color:  shouldUseColor(),
+		format:   format,
+		quiet:    quiet,
+		color:    shouldUseColor(),
+		out:      os.Stdout,
+		redactor: NewRedactor(DefaultRedactMode(format)),
 	}
 }
 
+// WithWriter sets where results are written (stdout by default)
+func (f *Formatter) WithWriter(w io.Writer) *Formatter {
+	f.out = w
+	return f
+}
+
+// WithTemplate sets the text/template file used by the template format
+func (f *Formatter) WithTemplate(path string) *Formatter {
+	f.templatePath = path
+	return f
+}
+
+// WithBaseline sets previous results that reports compare against
+func (f *Formatter) WithBaseline(b *Baseline) *Formatter {
+	f.baseline = b
+	return f
+}
+
+// WithRedaction overrides the format's default redaction mode
+func (f *Formatter) WithRedaction(mode RedactMode) *Formatter {
+	f.redactor.mode = mode
+	return f
+}
+
+// WithSecurityOptions makes redaction recognise secrets the way the
+// configured security rule does
+func (f *Formatter) WithSecurityOptions(opts rules.SecurityOptions) *Formatter {
+	f.redactor.WithSecurityOptions(opts)
+	return f
+}
+
 func shouldUseColor() bool {
 	// Check if output is a terminal and color is supported
 	if os.Getenv("NO_COLOR") != "" {
@@ -49,15 +87,32 @@ func shouldUseColor() bool {
 	return true
 }
 
-func (f *Formatter) PrintResults(issues []issues.Issue, files []string) {
+func (f *Formatter) PrintResults(issues []issues.Issue, files []string) error {
+	// Mask sensitive values before any formatter sees them
+	issues = f.redactor.Issues(issues)
+
 	switch f.format {
 	case "json":
-		f.printJSON(issues, files)
+		return f.printJSON(issues, files)
 	case "github":
-		f.printGitHub(issues)
+		f.printGitHub(issues, files)
+	case "template":
+		if len(issues) == 0 && f.quiet {
+			return nil
+		}
+		return f.printTemplate(NewReport(issues, files))
+	case "html":
+		return f.printHTML(NewReport(issues, files))
+	case "markdown":
+		if len(issues) == 0 && f.quiet {
+			return nil
+		}
+		f.printMarkdown(issues, files)
 	default:
 		f.printPretty(issues, files)
 	}
+
+	return nil
 }
 
 func (f *Formatter) printPretty(issueList []issues.Issue, files []string) {
@@ -68,18 +123,8 @@ func (f *Formatter) printPretty(issueList []issues.Issue, files []string) {
 		return
 	}
 
-	// Group issues by file
-	fileIssues := make(map[string][]issues.Issue)
-	for _, issue := range issueList {
-		fileIssues[issue.File] = append(fileIssues[issue.File], issue)
-	}
-
-	// Sort files for consistent output
-	sortedFiles := make([]string, 0, len(fileIssues))
-	for file := range fileIssues {
-		sortedFiles = append(sortedFiles, file)
-	}
-	sort.Strings(sortedFiles)
+	// Group issues by file, sorted by file name and line number
+	sortedFiles, fileIssues := groupByFile(issueList)
 
 	// Print header
 	f.colorPrint(Bold+Red, "🚨 Issues found:\n\n")
@@ -92,15 +137,10 @@ func (f *Formatter) printPretty(issueList []issues.Issue, files []string) {
 		f.colorPrint(Bold+Blue, fmt.Sprintf("📁 %s\n", file))
 		f.colorPrint(Gray, strings.Repeat("─", len(file)+4)+"\n")
 
-		// Sort issues by line number
-		sort.Slice(issues, func(i, j int) bool {
-			return issues[i].FirstLine < issues[j].FirstLine
-		})
-
 		for _, issue := range issues {
 			f.printIssue(issue)
 		}
-		fmt.Println()
+		fmt.Fprintln(f.out)
 	}
 
 	// Summary
@@ -113,19 +153,23 @@ func (f *Formatter) printIssue(issue issues.Issue) {
 	color := f.getIssueColor(issue.Name)
 
 	// Main issue line
+	label := fmt.Sprintf("%s '%s'", issue.Name, issue.Key)
+	if issue.Confidence != "" {
+		label += fmt.Sprintf(" (%s confidence)", issue.Confidence)
+	}
 	if issue.Line > 0 && issue.FirstLine > 0 && issue.Line != issue.FirstLine {
-		f.colorPrint(color, fmt.Sprintf("  %s Line %d-%d: %s '%s'\n",
-			icon, issue.FirstLine, issue.Line, issue.Name, issue.Key))
+		f.colorPrint(color, fmt.Sprintf("  %s Line %d-%d: %s\n",
+			icon, issue.FirstLine, issue.Line, label))
 	} else if issue.Line > 0 || issue.FirstLine > 0 {
 		lineNum := issue.Line
 		if lineNum == 0 {
 			lineNum = issue.FirstLine
 		}
-		f.colorPrint(color, fmt.Sprintf("  %s Line %d: %s '%s'\n",
-			icon, lineNum, issue.Name, issue.Key))
+		f.colorPrint(color, fmt.Sprintf("  %s Line %d: %s\n",
+			icon, lineNum, label))
 	} else {
-		f.colorPrint(color, fmt.Sprintf("  %s %s '%s'\n",
-			icon, issue.Name, issue.Key))
+		f.colorPrint(color, fmt.Sprintf("  %s %s\n",
+			icon, label))
 	}
 
 	// Recommendations
@@ -136,7 +180,7 @@ func (f *Formatter) printIssue(issue issues.Issue) {
 	}
 }
 
-func (f *Formatter) printJSON(issueList []issues.Issue, files []string) {
+func (f *Formatter) printJSON(issueList []issues.Issue, files []string) error {
 	output := struct {
 		Issues []issues.Issue `json:"issues"`
 		Files  []string       `json:"files"`
@@ -147,43 +191,31 @@ func (f *Formatter) printJSON(issueList []issues.Issue, files []string) {
 		Count:  len(issueList),
 	}
 
-	encoder := json.NewEncoder(os.Stdout)
+	encoder := json.NewEncoder(f.out)
 	encoder.SetIndent("", "  ")
-	encoder.Encode(output)
-}
-
-func (f *Formatter) printGitHub(issues []issues.Issue) {
-	// GitHub Actions annotation format
-	for _, issue := range issues {
-		level := "error"
-		if strings.Contains(strings.ToLower(issue.Name), "warning") ||
-			strings.Contains(strings.ToLower(issue.Name), "convention") {
-			level = "warning"
-		}
-
-		line := issue.FirstLine
-		if line == 0 {
-			line = issue.Line
-		}
-		if line == 0 {
-			line = 1
-		}
-
-		fmt.Printf("::%s file=%s,line=%d::%s '%s'\n",
-			level, issue.File, line, issue.Name, issue.Key)
-	}
+	return encoder.Encode(output)
 }
 
 func (f *Formatter) colorPrint(color, text string) {
 	if f.color {
-		fmt.Print(color + text + Reset)
+		fmt.Fprint(f.out, color+text+Reset)
 	} else {
-		fmt.Print(text)
+		fmt.Fprint(f.out, text)
 	}
 }
 
 func (f *Formatter) getIssueIcon(issueName string) string {
 	switch {
+	case strings.Contains(strings.ToLower(issueName), "deployment"):
+		return "🚢"
+	case strings.Contains(strings.ToLower(issueName), "invalid value"):
+		return "🎯"
+	case strings.Contains(strings.ToLower(issueName), "inconsistent"):
+		return "🔀"
+	case strings.Contains(strings.ToLower(issueName), "example"):
+		return "📋"
+	case strings.Contains(strings.ToLower(issueName), "unused"):
+		return "🧹"
 	case strings.Contains(strings.ToLower(issueName), "duplicate"):
 		return "🔄"
 	case strings.Contains(strings.ToLower(issueName), "missing"):
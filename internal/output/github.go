@@ -0,0 +1,91 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tahcohcat/ecolint/domain/issues"
+	"github.com/tahcohcat/ecolint/rules"
+)
+
+// printGitHub prints GitHub Actions workflow commands, one annotation per
+// issue followed by a notice with the totals
+func (f *Formatter) printGitHub(issueList []issues.Issue, files []string) {
+	report := NewReport(issueList, files)
+
+	for _, file := range report.Files {
+		for _, issue := range file.Issues {
+			f.printAnnotation(issue)
+		}
+	}
+
+	if report.Summary.Issues == 0 {
+		if !f.quiet {
+			fmt.Fprintf(f.out, "::notice title=ecolint::%s\n",
+				escapeData(fmt.Sprintf("No issues found in %s", Plural(report.Summary.LintedFiles, "file"))))
+		}
+		return
+	}
+
+	summary := fmt.Sprintf("Found %s across %s: %s, %s, %s",
+		Plural(report.Summary.Issues, "issue"),
+		Plural(report.Summary.Files, "file"),
+		Plural(report.Summary.BySeverity[rules.SeverityError], "error"),
+		Plural(report.Summary.BySeverity[rules.SeverityWarning], "warning"),
+		Plural(report.Summary.BySeverity[rules.SeverityNotice], "notice"))
+	fmt.Fprintf(f.out, "::notice title=ecolint::%s\n", escapeData(summary))
+}
+
+func (f *Formatter) printAnnotation(issue ReportIssue) {
+	// Annotations need a line; issues without one (e.g. missing variables)
+	// are attached to the top of the file
+	line := issue.FirstLine
+	if line == 0 {
+		line = issue.Line
+	}
+	if line == 0 {
+		line = 1
+	}
+
+	// Issues cover whole lines, so columns always start at 1
+	properties := []string{
+		"file=" + escapeProperty(issue.File),
+		fmt.Sprintf("line=%d", line),
+		fmt.Sprintf("endLine=%d", line),
+		"col=1",
+		"title=" + escapeProperty(fmt.Sprintf("ecolint %s: %s", issue.Rule.ID, issue.Name)),
+	}
+
+	// FirstLine and Line are separate occurrences, such as the two
+	// definitions of a duplicate, not a range; name the other one
+	message := fmt.Sprintf("%s '%s'", issue.Name, issue.Key)
+	if issue.Line > 0 && issue.Line != line {
+		message += fmt.Sprintf(" (line %d and line %d)", line, issue.Line)
+	}
+	if issue.Confidence != "" {
+		message += fmt.Sprintf(" (%s confidence)", issue.Confidence)
+	}
+	if issue.Rule.Description != "" {
+		message += "\n" + issue.Rule.Description
+	}
+	if len(issue.Recommendations) > 0 {
+		message += "\n\nRecommendations:"
+		for _, rec := range issue.Recommendations {
+			message += "\n- " + rec
+		}
+	}
+
+	fmt.Fprintf(f.out, "::%s %s::%s\n",
+		issue.Rule.Severity, strings.Join(properties, ","), escapeData(message))
+}
+
+// escapeData escapes an annotation message as GitHub's toolkit does
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes an annotation property value, which additionally
+// can't contain the ':' and ',' separators
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
@@ -0,0 +1,39 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/tahcohcat/ecolint/domain/issues"
+)
+
+func TestPrintGitHub(t *testing.T) {
+	issueList := []issues.Issue{
+		issues.NewIssue("duplicate variable", "PORT", "a,b:c.env", 2, 5, []string{"100% sure\r\nreally"}),
+		issues.NewIssue("naming convention violation", "apiKey", "a,b:c.env", 7, 7, nil),
+	}
+
+	var buf bytes.Buffer
+	if err := NewFormatter("github", false).WithWriter(&buf).PrintResults(issueList, []string{"a,b:c.env"}); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := []string{
+		"::error file=a%2Cb%3Ac.env,line=2,endLine=2,col=1,title=ecolint duplicate%3A duplicate variable::" +
+			"duplicate variable 'PORT' (line 2 and line 5)%0AVariable is defined more than once%0A%0ARecommendations:%0A- 100%25 sure%0D%0Areally",
+		"::warning file=a%2Cb%3Ac.env,line=7,endLine=7,col=1,title=ecolint convention%3A naming convention violation::" +
+			"naming convention violation 'apiKey'%0AVariable name does not follow UPPER_SNAKE_CASE conventions",
+		"::notice title=ecolint::Found 2 issues across 1 file: 1 error, 1 warning, 0 notices",
+	}
+
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), buf.String())
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d:\n got %s\nwant %s", i+1, lines[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package output
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+
+	"github.com/tahcohcat/ecolint/rules"
+)
+
+// reportHTML is a self-contained page: styles and scripts are inline so the
+// report can be handed over as a single file
+//
+//go:embed report.html.tmpl
+var reportHTML string
+
+var htmlTemplate = template.Must(template.New("report.html").
+	Funcs(template.FuncMap{
+		"plural":  Plural,
+		"percent": percent,
+		"severities": func() []rules.Severity {
+			return []rules.Severity{rules.SeverityError, rules.SeverityWarning, rules.SeverityNotice}
+		},
+	}).
+	Parse(reportHTML))
+
+func (f *Formatter) printHTML(report Report) error {
+	if err := htmlTemplate.Execute(f.out, report); err != nil {
+		return fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	return nil
+}
+
+// percent returns n as a whole percentage of total, for dashboard bar widths
+func percent(n, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return n * 100 / total
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/tahcohcat/ecolint/domain/issues"
+)
+
+var (
+	htmlBarRow   = regexp.MustCompile(`<div class="bar-row"><span class="label"[^>]*>([^<]*)</span>.*?<span>(\d+)</span></div>`)
+	htmlExternal = regexp.MustCompile(`(?i)(\b(src|href)\s*=|<link\b|@import|url\()`)
+)
+
+func TestPrintHTML(t *testing.T) {
+	issueList := []issues.Issue{
+		issues.NewIssue("duplicate variable", "PORT", ".env", 2, 5, nil),
+		issues.NewIssue("duplicate variable", "HOST", ".env", 3, 6, nil),
+		issues.NewIssue("naming convention violation", "apiKey", ".env", 7, 7, nil),
+		issues.NewIssue("empty value", "DEBUG", "api/.env.production", 3, 3, nil),
+		issues.NewIssue("variable not in deployment", "LOCAL_ONLY", "api/.env.production", 4, 4, nil),
+	}
+
+	var buf bytes.Buffer
+	if err := NewFormatter("html", false).WithWriter(&buf).PrintResults(issueList, []string{".env", "api/.env.production", ".env.local"}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	panels := map[string]string{
+		"rule":     "convention=1 deployment=1 duplicate=2 empty_values=1",
+		"file":     ".env=3 api/.env.production=2",
+		"severity": "error=2 warning=2 notice=1",
+	}
+	for panel, want := range panels {
+		t.Run("per "+panel, func(t *testing.T) {
+			heading := "<h3>Issues per " + panel + "</h3>"
+			start := strings.Index(out, heading)
+			if start < 0 {
+				t.Fatalf("missing %q", heading)
+			}
+			section := out[start:]
+			section = section[:strings.Index(section, "</div>\n  </div>")+len("</div>")]
+
+			var got []string
+			for _, m := range htmlBarRow.FindAllStringSubmatch(section, -1) {
+				got = append(got, m[1]+"="+m[2])
+			}
+			if strings.Join(got, " ") != want {
+				t.Errorf("counts = %q, want %q", strings.Join(got, " "), want)
+			}
+		})
+	}
+
+	for _, card := range []string{
+		`<div class="muted">Issues</div><div class="value">5</div>`,
+		`<div class="muted">Files with issues</div><div class="value">2</div>`,
+		`<div class="muted">Files linted</div><div class="value">3</div>`,
+		`<div class="muted">error</div><div class="value">2</div>`,
+	} {
+		if !strings.Contains(out, card) {
+			t.Errorf("missing summary card %s", card)
+		}
+	}
+
+	if m := htmlExternal.FindString(out); m != "" {
+		t.Errorf("report is not self-contained, found %q", m)
+	}
+}
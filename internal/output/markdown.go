@@ -0,0 +1,155 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tahcohcat/ecolint/domain/issues"
+)
+
+// printMarkdown renders a summary suitable for PR comments and
+// $GITHUB_STEP_SUMMARY, built on the same grouping as printPretty
+func (f *Formatter) printMarkdown(issueList []issues.Issue, files []string) {
+	report := NewReport(issueList, files)
+	w := f.out
+
+	fmt.Fprintln(w, "## 🌱 ecolint")
+	fmt.Fprintln(w)
+
+	if report.Summary.Issues == 0 {
+		fmt.Fprintf(w, "✅ No issues found across %s. Your environment is squeaky clean!\n", Plural(report.Summary.LintedFiles, "file"))
+	} else {
+		fmt.Fprintf(w, "🚨 Found **%s** across **%s** (%d linted)\n",
+			Plural(report.Summary.Issues, "issue"), Plural(report.Summary.Files, "file"), report.Summary.LintedFiles)
+	}
+
+	var newIssues map[string]int
+	if f.baseline != nil {
+		diff := f.baseline.Diff(issueList)
+		newIssues = make(map[string]int)
+		for _, issue := range diff.New {
+			newIssues[fingerprint(issue)]++
+		}
+		f.printMarkdownBaseline(diff)
+	}
+
+	if report.Summary.Issues == 0 {
+		return
+	}
+
+	// Summary table: files × rules
+	fmt.Fprintln(w)
+	fmt.Fprint(w, "| File |")
+	for _, rule := range report.Rules {
+		fmt.Fprintf(w, " %s |", rule.ID)
+	}
+	fmt.Fprintln(w, " Total |")
+
+	fmt.Fprint(w, "|------|")
+	fmt.Fprint(w, strings.Repeat("---:|", len(report.Rules)))
+	fmt.Fprintln(w, "---:|")
+
+	for _, file := range report.Files {
+		counts := make(map[string]int)
+		for _, issue := range file.Issues {
+			counts[issue.Rule.ID]++
+		}
+
+		fmt.Fprintf(w, "| %s |", mdCode(file.Path))
+		for _, rule := range report.Rules {
+			if n := counts[rule.ID]; n > 0 {
+				fmt.Fprintf(w, " %d |", n)
+			} else {
+				fmt.Fprint(w, " – |")
+			}
+		}
+		fmt.Fprintf(w, " %d |\n", len(file.Issues))
+	}
+
+	fmt.Fprint(w, "| **Total** |")
+	for _, rule := range report.Rules {
+		fmt.Fprintf(w, " **%d** |", rule.Count)
+	}
+	fmt.Fprintf(w, " **%d** |\n", report.Summary.Issues)
+
+	// One collapsible block per file
+	for _, file := range report.Files {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "<details>")
+		fmt.Fprintf(w, "<summary><b>📁 %s</b> — %s</summary>\n\n",
+			htmlEscape(file.Path), Plural(len(file.Issues), "issue"))
+
+		for _, issue := range file.Issues {
+			marker := ""
+			if fp := fingerprint(issue.Issue); newIssues[fp] > 0 {
+				newIssues[fp]--
+				marker = "🆕 "
+			}
+
+			confidence := ""
+			if issue.Confidence != "" {
+				confidence = fmt.Sprintf(" (%s confidence)", issue.Confidence)
+			}
+			fmt.Fprintf(w, "- %s%s%s **%s** %s `%s`%s\n",
+				marker, f.getIssueIcon(issue.Name), mdLocation(issue.Issue), issue.Rule.Severity, issue.Name, mdInline(issue.Key), confidence)
+			for _, rec := range issue.Recommendations {
+				fmt.Fprintf(w, "  - 💡 %s\n", rec)
+			}
+		}
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "</details>")
+	}
+}
+
+func (f *Formatter) printMarkdownBaseline(diff BaselineDiff) {
+	w := f.out
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "### New vs baseline")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| 🆕 New | ♻️ Unchanged | ✅ Fixed |")
+	fmt.Fprintln(w, "|---:|---:|---:|")
+	fmt.Fprintf(w, "| %d | %d | %d |\n", len(diff.New), len(diff.Unchanged), len(diff.Fixed))
+
+	if len(diff.New) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "**New issues:**")
+		fmt.Fprintln(w)
+		sortedFiles, fileIssues := groupByFile(diff.New)
+		for _, file := range sortedFiles {
+			for _, issue := range fileIssues[file] {
+				fmt.Fprintf(w, "- %s%s %s `%s`\n", mdCode(file), mdLocation(issue), issue.Name, mdInline(issue.Key))
+			}
+		}
+	}
+}
+
+// mdLocation formats an issue's line range, e.g. " line 2-3:" or "" when unknown
+func mdLocation(issue issues.Issue) string {
+	switch {
+	case issue.Line > 0 && issue.FirstLine > 0 && issue.Line != issue.FirstLine:
+		return fmt.Sprintf(" line %d-%d:", issue.FirstLine, issue.Line)
+	case issue.FirstLine > 0:
+		return fmt.Sprintf(" line %d:", issue.FirstLine)
+	case issue.Line > 0:
+		return fmt.Sprintf(" line %d:", issue.Line)
+	default:
+		return ""
+	}
+}
+
+// mdCode formats a value as inline code that is also safe in a table cell
+func mdCode(s string) string {
+	return "`" + strings.ReplaceAll(mdInline(s), "|", `\|`) + "`"
+}
+
+// mdInline strips characters that would break out of an inline code span
+func mdInline(s string) string {
+	s = strings.ReplaceAll(s, "`", "'")
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
+
+func htmlEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(s)
+}
@@ -0,0 +1,129 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/tahcohcat/ecolint/domain/issues"
+)
+
+func TestPrintMarkdown(t *testing.T) {
+	issueList := []issues.Issue{
+		issues.NewIssue("duplicate variable", "PORT", ".env", 2, 5, []string{"Remove one of the duplicate definitions"}),
+		issues.NewIssue("naming convention violation", "apiKey", ".env", 7, 7, nil),
+		issues.NewIssue("empty value", "a|b`c", "config/.env.production", 3, 3, nil),
+	}
+	baseline := NewBaseline([]issues.Issue{
+		issues.NewIssue("duplicate variable", "PORT", ".env", 1, 4, nil),
+		issues.NewIssue("missing required variable", "API_KEY", ".env", 0, 0, nil),
+	})
+
+	tests := []struct {
+		name     string
+		issues   []issues.Issue
+		baseline *Baseline
+		expected string
+	}{
+		{
+			name:   "summary table and details",
+			issues: issueList,
+			expected: "## 🌱 ecolint\n" +
+				"\n" +
+				"🚨 Found **3 issues** across **2 files** (3 linted)\n" +
+				"\n" +
+				"| File | convention | duplicate | empty_values | Total |\n" +
+				"|------|---:|---:|---:|---:|\n" +
+				"| `.env` | 1 | 1 | – | 2 |\n" +
+				"| `config/.env.production` | – | – | 1 | 1 |\n" +
+				"| **Total** | **1** | **1** | **1** | **3** |\n" +
+				"\n" +
+				"<details>\n" +
+				"<summary><b>📁 .env</b> — 2 issues</summary>\n" +
+				"\n" +
+				"- 🔄 line 2-5: **error** duplicate variable `PORT`\n" +
+				"  - 💡 Remove one of the duplicate definitions\n" +
+				"- 📐 line 7: **warning** naming convention violation `apiKey`\n" +
+				"\n" +
+				"</details>\n" +
+				"\n" +
+				"<details>\n" +
+				"<summary><b>📁 config/.env.production</b> — 1 issue</summary>\n" +
+				"\n" +
+				"- 🗳️ line 3: **warning** empty value `a|b'c`\n" +
+				"\n" +
+				"</details>\n",
+		},
+		{
+			name:     "new issues against a baseline",
+			issues:   issueList,
+			baseline: baseline,
+			expected: "## 🌱 ecolint\n" +
+				"\n" +
+				"🚨 Found **3 issues** across **2 files** (3 linted)\n" +
+				"\n" +
+				"### New vs baseline\n" +
+				"\n" +
+				"| 🆕 New | ♻️ Unchanged | ✅ Fixed |\n" +
+				"|---:|---:|---:|\n" +
+				"| 2 | 1 | 1 |\n" +
+				"\n" +
+				"**New issues:**\n" +
+				"\n" +
+				"- `.env` line 7: naming convention violation `apiKey`\n" +
+				"- `config/.env.production` line 3: empty value `a|b'c`\n" +
+				"\n" +
+				"| File | convention | duplicate | empty_values | Total |\n" +
+				"|------|---:|---:|---:|---:|\n" +
+				"| `.env` | 1 | 1 | – | 2 |\n" +
+				"| `config/.env.production` | – | – | 1 | 1 |\n" +
+				"| **Total** | **1** | **1** | **1** | **3** |\n" +
+				"\n" +
+				"<details>\n" +
+				"<summary><b>📁 .env</b> — 2 issues</summary>\n" +
+				"\n" +
+				"- 🔄 line 2-5: **error** duplicate variable `PORT`\n" +
+				"  - 💡 Remove one of the duplicate definitions\n" +
+				"- 🆕 📐 line 7: **warning** naming convention violation `apiKey`\n" +
+				"\n" +
+				"</details>\n" +
+				"\n" +
+				"<details>\n" +
+				"<summary><b>📁 config/.env.production</b> — 1 issue</summary>\n" +
+				"\n" +
+				"- 🆕 🗳️ line 3: **warning** empty value `a|b'c`\n" +
+				"\n" +
+				"</details>\n",
+		},
+		{
+			name: "no issues",
+			expected: "## 🌱 ecolint\n" +
+				"\n" +
+				"✅ No issues found across 3 files. Your environment is squeaky clean!\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			formatter := NewFormatter("markdown", false).WithWriter(&buf)
+			if tt.baseline != nil {
+				formatter.WithBaseline(tt.baseline)
+			}
+			if err := formatter.PrintResults(tt.issues, []string{".env", "config/.env.production", ".env.local"}); err != nil {
+				t.Fatal(err)
+			}
+
+			got := strings.Split(buf.String(), "\n")
+			want := strings.Split(tt.expected, "\n")
+			if len(got) != len(want) {
+				t.Fatalf("got %d lines, want %d:\n%s", len(got), len(want), buf.String())
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Errorf("line %d:\n got %s\nwant %s", i+1, got[i], want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tahcohcat/ecolint/domain/issues"
+	"github.com/tahcohcat/ecolint/rules"
+)
+
+// RedactMode controls how sensitive values are masked before printing
+type RedactMode string
+
+const (
+	RedactNone    RedactMode = "none"
+	RedactPartial RedactMode = "partial"
+	RedactFull    RedactMode = "full"
+)
+
+// fullMask is fixed-length so masked output doesn't leak the value length
+const fullMask = "********"
+
+// wordScrubLength is the length below which values are only scrubbed from
+// free text such as recommendations where they stand as a whole token, so
+// a short password like "admin" doesn't mask part of "administrator"
+const wordScrubLength = 8
+
+// ParseRedactMode validates a --redact flag or config value
+func ParseRedactMode(s string) (RedactMode, error) {
+	switch mode := RedactMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case RedactNone, RedactPartial, RedactFull:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid redact mode %q (expected none, partial or full)", s)
+	}
+}
+
+// DefaultRedactMode returns the redaction used when none is configured.
+// Anything other than terminal output usually ends up in CI logs or shared
+// reports, so it masks by default.
+func DefaultRedactMode(format string) RedactMode {
+	switch format {
+	case "", "pretty":
+		return RedactNone
+	default:
+		return RedactFull
+	}
+}
+
+// Redactor masks values of sensitive variables in issues
+type Redactor struct {
+	mode     RedactMode
+	security rules.SecurityOptions
+}
+
+func NewRedactor(mode RedactMode) *Redactor {
+	return &Redactor{mode: mode, security: rules.DefaultSecurityOptions()}
+}
+
+// WithSecurityOptions sets the detectors and entropy thresholds used to
+// recognise secrets, so values found by configured detectors are masked too
+func (r *Redactor) WithSecurityOptions(opts rules.SecurityOptions) *Redactor {
+	r.security = opts
+	return r
+}
+
+// Issues returns redacted copies of the given issues
+func (r *Redactor) Issues(issueList []issues.Issue) []issues.Issue {
+	if r.mode == RedactNone {
+		return issueList
+	}
+
+	out := make([]issues.Issue, len(issueList))
+	for i, issue := range issueList {
+		out[i] = r.Issue(issue)
+	}
+	return out
+}
+
+// Issue returns a redacted copy of a single issue
+func (r *Redactor) Issue(issue issues.Issue) issues.Issue {
+	if r.mode == RedactNone {
+		return issue
+	}
+
+	var secrets []string
+
+	// Values reported by rules. Whatever the security rule reports is a
+	// secret by definition, even if the redactor wouldn't recognise it.
+	if issue.Value != "" && (rules.Describe(issue.Name).ID == "security" || r.isSensitive(issue.Key, issue.Value)) {
+		secrets = append(secrets, issue.Value)
+		issue.Value = r.Mask(issue.Value)
+	}
+
+	// Parse issues put the raw line in Key, which may include a value.
+	// Without a name we can't tell whose value it is, so it is always masked.
+	if name, prefix, value := splitRawLine(issue.Key); value != "" && (name == "" || r.isSensitive(name, value)) {
+		secrets = append(secrets, value)
+		issue.Key = prefix + r.Mask(value)
+	} else {
+		issue.Key = r.scrub(issue.Key, secrets)
+	}
+
+	if len(secrets) == 0 {
+		return issue
+	}
+
+	if len(issue.Recommendations) > 0 {
+		recommendations := make([]string, len(issue.Recommendations))
+		for i, rec := range issue.Recommendations {
+			recommendations[i] = r.scrub(rec, secrets)
+		}
+		issue.Recommendations = recommendations
+	}
+
+	return issue
+}
+
+// Mask hides a value according to the redaction mode
+func (r *Redactor) Mask(value string) string {
+	switch r.mode {
+	case RedactNone:
+		return value
+	case RedactPartial:
+		// Keep a short prefix to help identify the value, never more than a
+		// quarter of it. Count runes so multi-byte characters aren't split.
+		runes := []rune(value)
+		keep := len(runes) / 4
+		if keep > 4 {
+			keep = 4
+		}
+		return string(runes[:keep]) + strings.Repeat("*", 4)
+	default:
+		return fullMask
+	}
+}
+
+func (r *Redactor) isSensitive(key, value string) bool {
+	return rules.IsSensitiveKey(key) || r.security.LooksLikeSecret(value)
+}
+
+func (r *Redactor) scrub(text string, secrets []string) string {
+	for _, secret := range secrets {
+		if len(secret) >= wordScrubLength {
+			text = strings.ReplaceAll(text, secret, r.Mask(secret))
+		} else {
+			text = replaceToken(text, secret, r.Mask(secret))
+		}
+	}
+	return text
+}
+
+// replaceToken replaces occurrences of old in text that aren't part of a
+// longer word, e.g. hunter2 in "postgres://app:hunter2@db"
+func replaceToken(text, old, new string) string {
+	var b strings.Builder
+	for {
+		i := strings.Index(text, old)
+		if i < 0 {
+			b.WriteString(text)
+			return b.String()
+		}
+		end := i + len(old)
+		before, _ := utf8.DecodeLastRuneInString(text[:i])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		b.WriteString(text[:i])
+		if (i == 0 || !isWordRune(before)) && (end == len(text) || !isWordRune(after)) {
+			b.WriteString(new)
+		} else {
+			b.WriteString(old)
+		}
+		text = text[end:]
+	}
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// splitRawLine splits a raw .env line ("KEY=value" or "KEY value") into the
+// variable name, everything preceding the value, and the value itself
+func splitRawLine(line string) (name, prefix, value string) {
+	idx := strings.IndexAny(line, "= \t")
+	if idx < 0 {
+		return line, line, ""
+	}
+
+	name = strings.TrimSpace(line[:idx])
+	rest := strings.TrimLeft(line[idx:], "= \t")
+	prefix = line[:len(line)-len(rest)]
+	value = strings.Trim(rest, `"'`)
+
+	return name, prefix, value
+}
@@ -0,0 +1,115 @@
+package output
+
+import (
+	"sort"
+
+	"github.com/tahcohcat/ecolint/domain/issues"
+	"github.com/tahcohcat/ecolint/rules"
+)
+
+// Report is the model rendered by the template format. It is documented in
+// README.md, so fields should only ever be added, not renamed.
+type Report struct {
+	Files       []FileReport // files with issues, sorted by path
+	Rules       []RuleReport // rules that reported issues, sorted by ID
+	Summary     Summary
+	LintedFiles []string // every file that was linted, with or without issues
+}
+
+// FileReport holds the issues of a single file, sorted by line
+type FileReport struct {
+	Path   string
+	Issues []ReportIssue
+}
+
+// ReportIssue is an issue together with the metadata of the rule behind it
+type ReportIssue struct {
+	issues.Issue
+	Rule rules.Metadata
+}
+
+// RuleReport is a rule's metadata with the number of issues it reported
+type RuleReport struct {
+	rules.Metadata
+	Count int
+}
+
+// Summary holds issue counts
+type Summary struct {
+	Issues      int
+	Files       int // files with at least one issue
+	LintedFiles int
+	ByRule      map[string]int
+	BySeverity  map[rules.Severity]int
+}
+
+// NewReport groups issues by file and collects summary counts
+func NewReport(issueList []issues.Issue, files []string) Report {
+	report := Report{
+		LintedFiles: files,
+		Summary: Summary{
+			Issues:      len(issueList),
+			LintedFiles: len(files),
+			ByRule:      make(map[string]int),
+			BySeverity:  make(map[rules.Severity]int),
+		},
+	}
+
+	ruleCounts := make(map[string]*RuleReport)
+	sortedFiles, fileIssues := groupByFile(issueList)
+
+	for _, file := range sortedFiles {
+		fileReport := FileReport{Path: file}
+		for _, issue := range fileIssues[file] {
+			meta := rules.Describe(issue.Name)
+			fileReport.Issues = append(fileReport.Issues, ReportIssue{Issue: issue, Rule: meta})
+
+			report.Summary.ByRule[meta.ID]++
+			report.Summary.BySeverity[meta.Severity]++
+
+			// Rules reporting several issue names are summarised once per ID
+			if rr, ok := ruleCounts[meta.ID]; ok {
+				rr.Count++
+			} else {
+				ruleCounts[meta.ID] = &RuleReport{Metadata: meta, Count: 1}
+			}
+		}
+		report.Files = append(report.Files, fileReport)
+	}
+	report.Summary.Files = len(report.Files)
+
+	for _, rr := range ruleCounts {
+		report.Rules = append(report.Rules, *rr)
+	}
+	sort.Slice(report.Rules, func(i, j int) bool {
+		return report.Rules[i].ID < report.Rules[j].ID
+	})
+
+	return report
+}
+
+// groupByFile groups issues by file, returning file names sorted
+// alphabetically and each file's issues sorted by line number
+func groupByFile(issueList []issues.Issue) ([]string, map[string][]issues.Issue) {
+	fileIssues := make(map[string][]issues.Issue)
+	for _, issue := range issueList {
+		fileIssues[issue.File] = append(fileIssues[issue.File], issue)
+	}
+
+	// Sort files for consistent output
+	sortedFiles := make([]string, 0, len(fileIssues))
+	for file := range fileIssues {
+		sortedFiles = append(sortedFiles, file)
+	}
+	sort.Strings(sortedFiles)
+
+	// Sort issues by line number
+	for _, file := range sortedFiles {
+		fileList := fileIssues[file]
+		sort.SliceStable(fileList, func(i, j int) bool {
+			return fileList[i].FirstLine < fileList[j].FirstLine
+		})
+	}
+
+	return sortedFiles, fileIssues
+}
@@ -0,0 +1,134 @@
+package output
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// IssueGroup is a named set of issues returned by the groupBy template helper
+type IssueGroup struct {
+	Name   string
+	Issues []ReportIssue
+}
+
+// Issues returns every issue in the report, in file then line order
+func (r Report) Issues() []ReportIssue {
+	var all []ReportIssue
+	for _, file := range r.Files {
+		all = append(all, file.Issues...)
+	}
+	return all
+}
+
+func (f *Formatter) printTemplate(report Report) error {
+	if f.templatePath == "" {
+		return fmt.Errorf("--format template requires --template <path>")
+	}
+
+	tmpl, err := template.New(filepath.Base(f.templatePath)).
+		Funcs(f.templateFuncs()).
+		ParseFiles(f.templatePath)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	if err := tmpl.Execute(f.out, report); err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+
+	return nil
+}
+
+// templateFuncs returns the helper functions available to user templates
+func (f *Formatter) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"color":   f.colorize,
+		"plural":  Plural,
+		"groupBy": groupBy,
+		"join":    strings.Join,
+		"upper":   strings.ToUpper,
+		"lower":   strings.ToLower,
+		"csv":     csvField,
+	}
+}
+
+// colorize wraps text in the named color when color output is enabled
+func (f *Formatter) colorize(name, text string) string {
+	colors := map[string]string{
+		"red":    Red,
+		"yellow": Yellow,
+		"green":  Green,
+		"blue":   Blue,
+		"purple": Purple,
+		"cyan":   Cyan,
+		"gray":   Gray,
+		"bold":   Bold,
+	}
+
+	color, ok := colors[strings.ToLower(name)]
+	if !f.color || !ok {
+		return text
+	}
+	return color + text + Reset
+}
+
+// Plural formats a count with a singular or plural noun: plural 2 "issue" -> "2 issues".
+// An explicit plural form can be passed for irregular nouns.
+func Plural(n int, singular string, pluralForm ...string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	if len(pluralForm) > 0 {
+		return fmt.Sprintf("%d %s", n, pluralForm[0])
+	}
+	return fmt.Sprintf("%d %ss", n, singular)
+}
+
+// groupBy groups issues by "file", "rule", "severity", "name" or "key",
+// returning groups sorted by name
+func groupBy(field string, issueList []ReportIssue) ([]IssueGroup, error) {
+	var keyOf func(ReportIssue) string
+	switch strings.ToLower(field) {
+	case "file":
+		keyOf = func(i ReportIssue) string { return i.File }
+	case "rule":
+		keyOf = func(i ReportIssue) string { return i.Rule.ID }
+	case "severity":
+		keyOf = func(i ReportIssue) string { return string(i.Rule.Severity) }
+	case "name":
+		keyOf = func(i ReportIssue) string { return i.Name }
+	case "key":
+		keyOf = func(i ReportIssue) string { return i.Key }
+	default:
+		return nil, fmt.Errorf("groupBy: unknown field %q (expected file, rule, severity, name or key)", field)
+	}
+
+	index := make(map[string]int)
+	var groups []IssueGroup
+	for _, issue := range issueList {
+		name := keyOf(issue)
+		if i, ok := index[name]; ok {
+			groups[i].Issues = append(groups[i].Issues, issue)
+			continue
+		}
+		index[name] = len(groups)
+		groups = append(groups, IssueGroup{Name: name, Issues: []ReportIssue{issue}})
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+
+	return groups, nil
+}
+
+// csvField quotes a value for use as a CSV field when needed
+func csvField(value string) string {
+	if !strings.ContainsAny(value, ",\"\r\n") {
+		return value
+	}
+	return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+}
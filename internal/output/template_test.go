@@ -0,0 +1,56 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tahcohcat/ecolint/domain/issues"
+)
+
+func TestPrintTemplate(t *testing.T) {
+	tmpl := `{{ plural .Summary.Issues "issue" }} in {{ plural .Summary.LintedFiles "file" }}
+{{ range groupBy "severity" .Issues }}{{ .Name }}={{ len .Issues }}
+{{ end }}{{ range .Issues }}{{ csv .Key }},{{ .Rule.ID }}
+{{ end }}`
+
+	path := filepath.Join(t.TempDir(), "report.tmpl")
+	if err := os.WriteFile(path, []byte(tmpl), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	issueList := []issues.Issue{
+		issues.NewIssue("naming convention violation", "apiKey", "b.env", 3, 3, nil),
+		issues.NewIssue("duplicate variable", "PORT", "a.env", 1, 2, nil),
+		issues.NewIssue("malformed line", "A,B", "a.env", 4, 4, nil),
+	}
+
+	var buf bytes.Buffer
+	err := NewFormatter("template", false).
+		WithWriter(&buf).
+		WithTemplate(path).
+		PrintResults(issueList, []string{"a.env", "b.env", "c.env"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `3 issues in 3 files
+error=2
+warning=1
+PORT,duplicate
+"A,B",syntax
+apiKey,convention
+`
+	if buf.String() != want {
+		t.Errorf("template output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrintTemplateRequiresPath(t *testing.T) {
+	var buf bytes.Buffer
+	err := NewFormatter("template", false).WithWriter(&buf).PrintResults(nil, nil)
+	if err == nil {
+		t.Error("expected error when no template is set")
+	}
+}
@@ -0,0 +1,188 @@
+package scan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// CacheDir is the directory, relative to the scanned root, holding the cache
+const CacheDir = ".ecolint-cache"
+
+// cacheVersion must be bumped whenever scanning logic changes, so results
+// from older versions are discarded
+const cacheVersion = "5"
+
+// Cache stores per-file scan results between runs so that only changed files
+// are scanned again. Entries are keyed by path and content hash, and the
+// whole cache is dropped when the scanner version or patterns change.
+type Cache struct {
+	path string
+
+	mu   sync.Mutex
+	data cacheData
+	seen map[string]bool // files looked up or stored since opening
+}
+
+// cacheData is the on-disk format
+type cacheData struct {
+	Version string                `json:"version"`
+	Files   map[string]cachedFile `json:"files"`
+}
+
+type cachedFile struct {
+	Hash       string                   `json:"hash"`
+	Usages     []UsageResult            `json:"usages"`
+	EnvFiles   []string                 `json:"env_files,omitempty"`
+	EnvFrom    []cachedEnvFrom          `json:"env_from,omitempty"`
+	ConfigData map[string][]UsageResult `json:"config_data,omitempty"`
+}
+
+type cachedEnvFrom struct {
+	Source   string `json:"source"`
+	Prefix   string `json:"prefix,omitempty"`
+	Manifest string `json:"manifest"`
+}
+
+// DefaultCachePath returns the cache file for a project root
+func DefaultCachePath(root string) string {
+	return filepath.Join(root, CacheDir, "scan.json")
+}
+
+// OpenCache loads a cache file. A missing or unreadable cache starts empty.
+func OpenCache(path string) *Cache {
+	c := &Cache{path: path}
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &c.data)
+	}
+	if c.data.Files == nil {
+		c.data.Files = make(map[string]cachedFile)
+	}
+	c.seen = make(map[string]bool)
+	return c
+}
+
+// CleanCache removes the cache directory of a project root
+func CleanCache(root string) error {
+	return os.RemoveAll(filepath.Join(root, CacheDir))
+}
+
+// Save writes the cache, keeping only files seen since it was opened
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for path := range c.data.Files {
+		if !c.seen[path] {
+			delete(c.data.Files, path)
+		}
+	}
+
+	dir := filepath.Dir(c.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("cannot create cache directory: %w", err)
+	}
+	// Keep the cache out of version control without touching .gitignore
+	if _, err := os.Stat(filepath.Join(dir, ".gitignore")); os.IsNotExist(err) {
+		os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*\n"), 0644)
+	}
+
+	data, err := json.Marshal(c.data)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0644)
+}
+
+// use drops all entries if they were written by a different scanner
+func (c *Cache) use(version string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.data.Version != version {
+		c.data.Version = version
+		c.data.Files = make(map[string]cachedFile)
+	}
+}
+
+// lookup returns the cached result for a file if its content is unchanged
+func (c *Cache) lookup(path, hash string) (*ScanResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.seen[path] = true
+	entry, ok := c.data.Files[path]
+	if !ok || entry.Hash != hash {
+		return nil, false
+	}
+
+	result := &ScanResult{
+		Variables: make(map[string][]UsageResult),
+		Files:     []string{path},
+		Errors:    []error{},
+		EnvFiles:  entry.EnvFiles,
+	}
+	for _, usage := range entry.Usages {
+		result.Variables[usage.Variable] = append(result.Variables[usage.Variable], usage)
+	}
+	for _, ref := range entry.EnvFrom {
+		result.envFrom = append(result.envFrom, envFromRef{source: ref.Source, prefix: ref.Prefix, manifest: ref.Manifest})
+	}
+	if len(entry.ConfigData) > 0 {
+		result.configData = entry.ConfigData
+	}
+	return result, true
+}
+
+// store records the result of scanning a file
+func (c *Cache) store(path, hash string, result *ScanResult) {
+	// Results with errors may be incomplete, so scan them again next time
+	if len(result.Errors) > 0 {
+		return
+	}
+
+	entry := cachedFile{
+		Hash:       hash,
+		EnvFiles:   result.EnvFiles,
+		ConfigData: result.configData,
+	}
+
+	// Flatten in name order; usages of each variable keep their order
+	names := make([]string, 0, len(result.Variables))
+	for name := range result.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		entry.Usages = append(entry.Usages, result.Variables[name]...)
+	}
+	for _, ref := range result.envFrom {
+		entry.EnvFrom = append(entry.EnvFrom, cachedEnvFrom{Source: ref.source, Prefix: ref.prefix, Manifest: ref.manifest})
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seen[path] = true
+	c.data.Files[path] = entry
+}
+
+// cacheKey identifies what produced cached results: the scanner version and
+// every pattern in use, since custom patterns change what is found
+func (ps *ProjectScanner) cacheKey() string {
+	h := sha256.New()
+	fmt.Fprintln(h, cacheVersion)
+	for _, pattern := range ps.patterns {
+		fmt.Fprintln(h, pattern.Name, pattern.Language, pattern.Pattern.String(), pattern.Classify != nil)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func hashContent(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
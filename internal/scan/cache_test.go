@@ -0,0 +1,167 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestScanProjectCache(t *testing.T) {
+	root := writeProject(t, 12)
+	compose := "services:\n  api:\n    image: api\n    env_file: .env.api\n    environment:\n      - LOG_LEVEL=info\n"
+	if err := os.WriteFile(filepath.Join(root, "docker-compose.yml"), []byte(compose), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cachePath := DefaultCachePath(root)
+
+	scan := func(ps *ProjectScanner) *ScanResult {
+		t.Helper()
+		result, err := ps.WithCache(OpenCache(cachePath)).ScanProject(root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Errors) > 0 {
+			t.Fatalf("unexpected errors: %v", result.Errors)
+		}
+		return result
+	}
+
+	want, err := NewProjectScanner().ScanProject(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := scan(NewProjectScanner())
+	if first.Cached != 0 {
+		t.Errorf("first scan: Cached = %d, want 0", first.Cached)
+	}
+	if _, err := os.Stat(cachePath); err != nil {
+		t.Fatalf("cache not saved: %v", err)
+	}
+
+	second := scan(NewProjectScanner())
+	if second.Cached != len(want.Files) {
+		t.Errorf("second scan: Cached = %d, want %d", second.Cached, len(want.Files))
+	}
+	if !reflect.DeepEqual(second.Variables, want.Variables) {
+		t.Errorf("cached variables differ from a fresh scan")
+	}
+	if !reflect.DeepEqual(second.Files, want.Files) || !reflect.DeepEqual(second.EnvFiles, want.EnvFiles) {
+		t.Errorf("cached files differ: got %v %v, want %v %v", second.Files, second.EnvFiles, want.Files, want.EnvFiles)
+	}
+
+	// A changed file is scanned again
+	changed := filepath.Join(root, "pkg0", "app0.js")
+	if err := os.WriteFile(changed, []byte("const k = process.env.NEW_KEY;\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	third := scan(NewProjectScanner())
+	if third.Cached != len(want.Files)-1 {
+		t.Errorf("after change: Cached = %d, want %d", third.Cached, len(want.Files)-1)
+	}
+	if len(third.Variables["NEW_KEY"]) != 1 {
+		t.Errorf("after change: NEW_KEY not discovered")
+	}
+	if _, ok := third.Variables["VAR_0"]; ok {
+		t.Errorf("after change: stale VAR_0 still reported")
+	}
+
+	// Different patterns invalidate every entry
+	custom := NewProjectScanner()
+	custom.patterns = append(custom.patterns, VariablePattern{
+		Name:     "Custom config",
+		Pattern:  regexp.MustCompile(`config\("([A-Z_][A-Z0-9_]*)"\)`),
+		Language: "javascript",
+	})
+	if got := scan(custom); got.Cached != 0 {
+		t.Errorf("new patterns: Cached = %d, want 0", got.Cached)
+	}
+}
+
+func TestCleanCache(t *testing.T) {
+	root := writeProject(t, 3)
+	if _, err := NewProjectScanner().WithCache(OpenCache(DefaultCachePath(root))).ScanProject(root); err != nil {
+		t.Fatal(err)
+	}
+	if err := CleanCache(root); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(root, CacheDir)); !os.IsNotExist(err) {
+		t.Errorf("cache directory still exists: %v", err)
+	}
+}
+
+func TestScanProjectCacheHelmChart(t *testing.T) {
+	root := t.TempDir()
+	values := "api:\n  env:\n    FEATURE_FLAGS: beta\n"
+	if err := os.WriteFile(filepath.Join(root, "values.yaml"), []byte(values), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cachePath := DefaultCachePath(root)
+
+	scan := func() *ScanResult {
+		t.Helper()
+		result, err := NewProjectScanner().WithCache(OpenCache(cachePath)).ScanProject(root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	if got := scan(); len(got.Variables["FEATURE_FLAGS"]) != 0 {
+		t.Fatalf("values.yaml without Chart.yaml: FEATURE_FLAGS = %v, want none", got.Variables["FEATURE_FLAGS"])
+	}
+
+	// Adding Chart.yaml turns the unchanged values.yaml into Helm values
+	if err := os.WriteFile(filepath.Join(root, "Chart.yaml"), []byte("name: api\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := scan(); len(got.Variables["FEATURE_FLAGS"]) != 1 {
+		t.Errorf("after adding Chart.yaml: FEATURE_FLAGS = %v, want one declaration", got.Variables["FEATURE_FLAGS"])
+	}
+
+	if err := os.Remove(filepath.Join(root, "Chart.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	if got := scan(); len(got.Variables["FEATURE_FLAGS"]) != 0 {
+		t.Errorf("after removing Chart.yaml: FEATURE_FLAGS = %v, want none", got.Variables["FEATURE_FLAGS"])
+	}
+}
+
+func TestScanProjectCacheGoPackage(t *testing.T) {
+	root := t.TempDir()
+	main := "package main\n\nfunc main() { _ = getEnv(\"DB_HOST\", \"localhost\") }\n"
+	if err := os.WriteFile(filepath.Join(root, "main.go"), []byte(main), 0644); err != nil {
+		t.Fatal(err)
+	}
+	writeHelper := func(body string) {
+		t.Helper()
+		src := "package main\n\nimport \"os\"\n\nfunc getEnv(key, fallback string) string {\n" + body + "}\n"
+		if err := os.WriteFile(filepath.Join(root, "config.go"), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cachePath := DefaultCachePath(root)
+
+	requirement := func() Requirement {
+		t.Helper()
+		result, err := NewProjectScanner().WithCache(OpenCache(cachePath)).ScanProject(root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result.Summarize("DB_HOST").Requirement
+	}
+
+	writeHelper("\tif v := os.Getenv(key); v != \"\" {\n\t\treturn v\n\t}\n\treturn fallback\n")
+	if got := requirement(); got != RequirementOptional {
+		t.Fatalf("with a fallback: Requirement = %q, want %q", got, RequirementOptional)
+	}
+
+	// main.go is unchanged, but the helper in config.go no longer falls back
+	writeHelper("\treturn os.Getenv(key)\n")
+	if got := requirement(); got != RequirementRequired {
+		t.Errorf("without a fallback: Requirement = %q, want %q", got, RequirementRequired)
+	}
+}
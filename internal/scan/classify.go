@@ -0,0 +1,158 @@
+package scan
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// process.env.X || 3000, process.env.X ?? "d", getenv('X') ?: 'd', os.getenv("X") or "d"
+	fallbackOperator = regexp.MustCompile(`^\s*(?:\|\||\?\?|\?:|\s+or\s+)\s*`)
+	// if (process.env.X), if (!process.env.X), if os.getenv("X"):, if not os.getenv("X"):
+	ifBefore = regexp.MustCompile(`\bif\s*\(?\s*(?:!|not\s+)?\s*$`)
+	ifAfter  = regexp.MustCompile(`^\s*(?:\)|:)`)
+	// [ -z "$X" ], [[ -n ${X} ]]
+	shellTestBefore = regexp.MustCompile(`-[zn]\s+"?$`)
+	// os.getenv("X", "default"), ENV.fetch("X", nil)
+	defaultArgument = regexp.MustCompile(`^\s*,\s*`)
+	// ENV.fetch("X") { "default" }, ENV.fetch("X") { |k| "default" }
+	rubyFetchBlock = regexp.MustCompile(`^\s*\)\s*\{\s*(?:\|[^|]*\|\s*)?`)
+	literalToken   = regexp.MustCompile(`^[^\s,;)}\]]+`)
+)
+
+// classifyUsage is the default classifier. A usage is optional when followed
+// by a fallback operator, an existence check when it is only the condition of
+// an if statement or shell test, and required otherwise.
+func classifyUsage(match []string, before, after string) (Requirement, string) {
+	if loc := fallbackOperator.FindStringIndex(after); loc != nil {
+		if def, ok := parseLiteral(after[loc[1]:]); ok {
+			return RequirementOptional, def
+		}
+		return RequirementOptional, ""
+	}
+
+	if ifBefore.MatchString(before) && ifAfter.MatchString(after) {
+		return RequirementExistence, ""
+	}
+	if shellTestBefore.MatchString(before) {
+		return RequirementExistence, ""
+	}
+
+	return RequirementRequired, ""
+}
+
+// classifyDefaultArgument handles calls taking the fallback as a second
+// argument, e.g. os.getenv("X", "default"). The pattern must stop after the
+// variable name's closing quote.
+func classifyDefaultArgument(match []string, before, after string) (Requirement, string) {
+	if loc := defaultArgument.FindStringIndex(after); loc != nil {
+		def, _ := parseLiteral(after[loc[1]:])
+		if def == "None" {
+			// os.getenv("X", None) behaves exactly like os.getenv("X")
+			return classifyUsage(match, before, closingParen(after))
+		}
+		return RequirementOptional, def
+	}
+	return classifyUsage(match, before, strings.TrimPrefix(after, ")"))
+}
+
+// classifyRubyFetch handles ENV.fetch, which raises unless given a default
+// argument or block
+func classifyRubyFetch(match []string, before, after string) (Requirement, string) {
+	if loc := defaultArgument.FindStringIndex(after); loc != nil {
+		def, _ := parseLiteral(after[loc[1]:])
+		return RequirementOptional, def
+	}
+	if loc := rubyFetchBlock.FindStringIndex(after); loc != nil {
+		def, _ := parseLiteral(after[loc[1]:])
+		return RequirementOptional, def
+	}
+	return RequirementRequired, ""
+}
+
+// classifyShellExpansion handles ${VAR:-default}, ${VAR:=default},
+// ${VAR:?message} and ${VAR:+alternate}. The pattern captures the name, the
+// operator and the word.
+func classifyShellExpansion(match []string, before, after string) (Requirement, string) {
+	if len(match) < 4 {
+		return RequirementRequired, ""
+	}
+	switch strings.TrimPrefix(match[2], ":") {
+	case "-", "=":
+		return RequirementOptional, unquote(match[3])
+	case "+":
+		return RequirementExistence, ""
+	default: // "?" fails when unset
+		return RequirementRequired, ""
+	}
+}
+
+// classifyExistence is used by patterns that only match existence checks,
+// such as "X" in os.environ
+func classifyExistence(match []string, before, after string) (Requirement, string) {
+	return RequirementExistence, ""
+}
+
+// classifyUnknown is used by patterns that only see a variable name and
+// cannot tell how it is used
+func classifyUnknown(match []string, before, after string) (Requirement, string) {
+	return "", ""
+}
+
+// parseLiteral reads a quoted string or a bare token (number, nil, constant)
+// at the start of s
+func parseLiteral(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", false
+	}
+
+	switch quote := s[0]; quote {
+	case '"', '\'', '`':
+		end := strings.IndexByte(s[1:], quote)
+		if end < 0 {
+			return "", false
+		}
+		return s[1 : end+1], true
+	}
+
+	token := literalToken.FindString(s)
+	return token, token != ""
+}
+
+// unquote strips one level of shell quoting from a parameter expansion word
+func unquote(s string) string {
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		return unquoted
+	}
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// closingParen returns the text after the call's closing parenthesis
+func closingParen(s string) string {
+	if i := strings.IndexByte(s, ')'); i >= 0 {
+		return s[i+1:]
+	}
+	return ""
+}
+
+// strictest combines requirements of several usages: a variable is required
+// if any usage requires it, otherwise optional if any usage supplies a default.
+// Declarations in manifests only count when no code reads the variable.
+func strictest(a, b Requirement) Requirement {
+	rank := map[Requirement]int{
+		"":                   0,
+		RequirementDeclared:  1,
+		RequirementExistence: 2,
+		RequirementOptional:  3,
+		RequirementRequired:  4,
+	}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
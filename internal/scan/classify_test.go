@@ -0,0 +1,71 @@
+package scan
+
+import "testing"
+
+func TestClassifyUsage(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		line        string
+		variable    string
+		requirement Requirement
+		def         string
+	}{
+		{name: "js plain", file: "app.js", line: `const url = process.env.DATABASE_URL;`, variable: "DATABASE_URL", requirement: RequirementRequired},
+		{name: "js or", file: "app.js", line: `const port = process.env.PORT || 3000;`, variable: "PORT", requirement: RequirementOptional, def: "3000"},
+		{name: "js nullish", file: "app.js", line: `const level = process.env.LOG_LEVEL ?? 'info';`, variable: "LOG_LEVEL", requirement: RequirementOptional, def: "info"},
+		{name: "js if", file: "app.js", line: `if (!process.env.SENTRY_DSN) {`, variable: "SENTRY_DSN", requirement: RequirementExistence},
+		{name: "js in", file: "app.js", line: `if ('CI' in process.env) {`, variable: "CI", requirement: RequirementExistence},
+		{name: "python getenv", file: "app.py", line: `key = os.getenv("API_KEY")`, variable: "API_KEY", requirement: RequirementRequired},
+		{name: "python getenv default", file: "app.py", line: `host = os.getenv("DB_HOST", "localhost")`, variable: "DB_HOST", requirement: RequirementOptional, def: "localhost"},
+		{name: "python getenv none", file: "app.py", line: `token = os.getenv("AUTH_TOKEN", None)`, variable: "AUTH_TOKEN", requirement: RequirementRequired},
+		{name: "python environ get", file: "app.py", line: `workers = os.environ.get('WORKERS', 4)`, variable: "WORKERS", requirement: RequirementOptional, def: "4"},
+		{name: "python or", file: "app.py", line: `region = os.getenv("AWS_REGION") or "us-east-1"`, variable: "AWS_REGION", requirement: RequirementOptional, def: "us-east-1"},
+		{name: "python in", file: "app.py", line: `if "DEBUG_MODE" in os.environ:`, variable: "DEBUG_MODE", requirement: RequirementExistence},
+		{name: "shell default", file: "run.sh", line: `PORT="${APP_PORT:-8080}"`, variable: "APP_PORT", requirement: RequirementOptional, def: "8080"},
+		{name: "shell assign default", file: "run.sh", line: `: "${CACHE_DIR:=/tmp/cache}"`, variable: "CACHE_DIR", requirement: RequirementOptional, def: "/tmp/cache"},
+		{name: "shell error", file: "run.sh", line: `echo "${DEPLOY_KEY:?must be set}"`, variable: "DEPLOY_KEY", requirement: RequirementRequired},
+		{name: "shell alternate", file: "run.sh", line: `ARGS="${VERBOSE_LOG:+-v}"`, variable: "VERBOSE_LOG", requirement: RequirementExistence},
+		{name: "shell test", file: "run.sh", line: `if [ -z "$REMOTE_HOST" ]; then`, variable: "REMOTE_HOST", requirement: RequirementExistence},
+		{name: "ruby fetch", file: "app.rb", line: `secret = ENV.fetch("SECRET_KEY_BASE")`, variable: "SECRET_KEY_BASE", requirement: RequirementRequired},
+		{name: "ruby fetch nil", file: "app.rb", line: `host = ENV.fetch("REDIS_HOST", nil)`, variable: "REDIS_HOST", requirement: RequirementOptional, def: "nil"},
+		{name: "ruby fetch block", file: "app.rb", line: `threads = ENV.fetch("RAILS_MAX_THREADS") { 5 }`, variable: "RAILS_MAX_THREADS", requirement: RequirementOptional, def: "5"},
+		{name: "ruby key", file: "app.rb", line: `enabled = ENV.key?("FEATURE_X")`, variable: "FEATURE_X", requirement: RequirementExistence},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewProjectScanner().scanFile(tt.file, []byte(tt.line+"\n"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			summary := result.Summarize(tt.variable)
+			if summary.Usages == 0 {
+				t.Fatalf("%s not found in %q", tt.variable, tt.line)
+			}
+			if summary.Requirement != tt.requirement {
+				t.Errorf("Requirement = %q, want %q", summary.Requirement, tt.requirement)
+			}
+			if got := summary.Defaults; tt.def != "" && (len(got) != 1 || got[0] != tt.def) {
+				t.Errorf("Defaults = %q, want [%q]", got, tt.def)
+			}
+		})
+	}
+}
+
+func TestGetRequiredVariablesSkipsOptional(t *testing.T) {
+	src := "const url = process.env.DATABASE_URL;\nconst port = process.env.PORT || 3000;\n"
+	result, err := NewProjectScanner().scanFile("app.js", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	required := result.GetRequiredVariables(0.5, 1)
+	if len(required) != 1 || required[0] != "DATABASE_URL" {
+		t.Errorf("GetRequiredVariables = %v, want [DATABASE_URL]", required)
+	}
+	if discovered := result.GetDiscoveredVariables(0.5, 1); len(discovered) != 2 {
+		t.Errorf("GetDiscoveredVariables = %v, want 2 variables", discovered)
+	}
+}
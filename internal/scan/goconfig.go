@@ -0,0 +1,661 @@
+package scan
+
+import (
+	"go/ast"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Import paths of the config libraries the Go scanner understands
+const (
+	envconfigPath = "github.com/kelseyhightower/envconfig"
+	caarlosEnv    = "github.com/caarlos0/env"
+	viperPath     = "github.com/spf13/viper"
+)
+
+// configImports holds the local names of imported config libraries
+type configImports struct {
+	envconfig string
+	env       string
+	viper     string
+}
+
+// collectConfigImports records the local names of envconfig, caarlos0/env
+// and viper, including versioned import paths such as caarlos0/env/v11
+func (gf *goFile) collectConfigImports() {
+	for _, imp := range gf.file.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		local := packageName(path)
+		if imp.Name != nil {
+			local = imp.Name.Name
+		}
+		if local == "_" || local == "." {
+			continue
+		}
+
+		switch {
+		case path == envconfigPath:
+			gf.imports.envconfig = local
+		case path == caarlosEnv || strings.HasPrefix(path, caarlosEnv+"/v"):
+			gf.imports.env = local
+		case path == viperPath:
+			gf.imports.viper = local
+		}
+	}
+}
+
+// configUsages discovers variables loaded through struct tags and viper
+func (gf *goFile) configUsages(filePath string) []UsageResult {
+	var usages []UsageResult
+	add := func(node ast.Node, usage UsageResult) {
+		usage.File = filePath
+		usage.Line = gf.fset.Position(node.Pos()).Line
+		usage.Context = gf.line(usage.Line)
+		usage.Language = "go"
+		usages = append(usages, usage)
+	}
+
+	structs := gf.structTypes()
+	if gf.imports.envconfig != "" || hasTaggedStruct(structs, "envconfig") {
+		gf.envconfigUsages(structs, add)
+	}
+	if gf.imports.env != "" || hasTaggedStruct(structs, "env") {
+		gf.envTagUsages(structs, add)
+	}
+	if gf.imports.viper != "" {
+		gf.viperUsages(add)
+	}
+
+	return usages
+}
+
+// envconfigUsages walks structs loaded with kelseyhightower/envconfig:
+//
+//	type Spec struct {
+//		Port     int    `default:"8080"`
+//		Database string `envconfig:"DATABASE_URL" required:"true"`
+//	}
+//	envconfig.Process("app", &spec) // APP_PORT, APP_DATABASE_URL
+//
+// Untagged fields are read too, so structs passed to Process are walked even
+// without envconfig tags.
+func (gf *goFile) envconfigUsages(structs map[string]*ast.StructType, add func(ast.Node, UsageResult)) {
+	callSites := make(map[string][]string) // type name -> prefixes
+	if pkg := gf.imports.envconfig; pkg != "" {
+		ast.Inspect(gf.file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) < 2 {
+				return true
+			}
+			if name := calleeName(call.Fun); name != pkg+".Process" && name != pkg+".MustProcess" {
+				return true
+			}
+			if typeName := gf.typeOf(call.Args[1]); typeName != "" {
+				prefix, _ := gf.resolveString(call.Args[0])
+				callSites[typeName] = append(callSites[typeName], prefix)
+			}
+			return true
+		})
+	}
+
+	for _, root := range rootStructs(structs, callSites, "envconfig") {
+		for _, prefix := range root.prefixes {
+			gf.envconfigFields(structs, root.st, strings.ToUpper(prefix), map[*ast.StructType]bool{root.st: true}, add)
+		}
+	}
+}
+
+func (gf *goFile) envconfigFields(structs map[string]*ast.StructType, st *ast.StructType, prefix string, seen map[*ast.StructType]bool, add func(ast.Node, UsageResult)) {
+	for _, field := range st.Fields.List {
+		tag := fieldTag(field)
+		name, hasName := tag.Lookup("envconfig")
+		if tag.Get("ignored") == "true" || name == "-" {
+			continue
+		}
+
+		for _, fieldName := range fieldNames(field) {
+			if !ast.IsExported(fieldName) {
+				continue
+			}
+
+			key := fieldName
+			if tag.Get("split_words") == "true" {
+				key = splitWords(fieldName)
+			}
+			if hasName && name != "" {
+				key = name
+			}
+			if prefix != "" {
+				key = prefix + "_" + key
+			}
+			key = strings.ToUpper(key)
+
+			if inner := structOf(structs, field.Type); inner != nil && !seen[inner] {
+				innerPrefix := key
+				if len(field.Names) == 0 {
+					innerPrefix = prefix // embedded structs don't add a prefix
+				}
+				seen[inner] = true
+				gf.envconfigFields(structs, inner, innerPrefix, seen, add)
+				delete(seen, inner)
+				continue
+			}
+
+			usage := UsageResult{
+				Variable:    key,
+				Pattern:     "Go envconfig struct tag",
+				Confidence:  0.9,
+				Requirement: RequirementOptional, // unset fields keep their zero value
+			}
+			if hasName {
+				usage.Confidence = 1.0
+			}
+			if def, ok := tag.Lookup("default"); ok {
+				usage.Default = def
+			} else if tag.Get("required") == "true" {
+				usage.Requirement = RequirementRequired
+			}
+			add(field, usage)
+		}
+	}
+}
+
+// envTagUsages walks structs loaded with caarlos0/env:
+//
+//	type Config struct {
+//		Port int      `env:"PORT" envDefault:"8080"`
+//		DB   DBConfig `envPrefix:"DB_"`
+//	}
+//	env.ParseWithOptions(&cfg, env.Options{Prefix: "APP_"}) // APP_PORT, APP_DB_...
+//
+// Only tagged fields are read.
+func (gf *goFile) envTagUsages(structs map[string]*ast.StructType, add func(ast.Node, UsageResult)) {
+	callSites := make(map[string][]string) // type name -> prefixes
+	if pkg := gf.imports.env; pkg != "" {
+		ast.Inspect(gf.file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			var typeName string
+			var opts ast.Expr
+			switch fun := call.Fun.(type) {
+			case *ast.IndexExpr:
+				// env.ParseAs[Config]() and env.ParseAsWithOptions[Config](opts)
+				name := calleeName(fun.X)
+				if name != pkg+".ParseAs" && name != pkg+".ParseAsWithOptions" {
+					return true
+				}
+				typeName = exprTypeName(fun.Index)
+				if len(call.Args) > 0 {
+					opts = call.Args[0]
+				}
+			default:
+				name := calleeName(call.Fun)
+				if (name != pkg+".Parse" && name != pkg+".ParseWithOptions") || len(call.Args) == 0 {
+					return true
+				}
+				typeName = gf.typeOf(call.Args[0])
+				if len(call.Args) > 1 {
+					opts = call.Args[1]
+				}
+			}
+
+			if typeName != "" {
+				callSites[typeName] = append(callSites[typeName], gf.optionsPrefix(opts))
+			}
+			return true
+		})
+	}
+
+	for _, root := range rootStructs(structs, callSites, "env") {
+		for _, prefix := range root.prefixes {
+			gf.envTagFields(structs, root.st, prefix, map[*ast.StructType]bool{root.st: true}, add)
+		}
+	}
+}
+
+func (gf *goFile) envTagFields(structs map[string]*ast.StructType, st *ast.StructType, prefix string, seen map[*ast.StructType]bool, add func(ast.Node, UsageResult)) {
+	for _, field := range st.Fields.List {
+		tag := fieldTag(field)
+		envTag, tagged := tag.Lookup("env")
+
+		if inner := structOf(structs, field.Type); inner != nil && !seen[inner] && envTag == "" {
+			seen[inner] = true
+			gf.envTagFields(structs, inner, prefix+tag.Get("envPrefix"), seen, add)
+			delete(seen, inner)
+			continue
+		}
+		if !tagged {
+			continue
+		}
+
+		options := strings.Split(envTag, ",")
+		if options[0] == "" || options[0] == "-" {
+			continue
+		}
+
+		usage := UsageResult{
+			Variable:    prefix + options[0],
+			Pattern:     "Go env struct tag",
+			Confidence:  1.0,
+			Requirement: RequirementOptional, // unset fields keep their zero value
+		}
+		if def, ok := tag.Lookup("envDefault"); ok {
+			usage.Default = def
+		} else {
+			for _, option := range options[1:] {
+				if option == "required" || option == "notEmpty" {
+					usage.Requirement = RequirementRequired
+				}
+			}
+		}
+		add(field, usage)
+	}
+}
+
+// optionsPrefix returns Prefix from an env.Options{...} literal
+func (gf *goFile) optionsPrefix(expr ast.Expr) string {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return ""
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Prefix" {
+			prefix, _ := gf.resolveString(kv.Value)
+			return prefix
+		}
+	}
+	return ""
+}
+
+// viperUsages discovers keys read through viper with AutomaticEnv, or bound
+// explicitly with BindEnv:
+//
+//	viper.SetEnvPrefix("app")
+//	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+//	viper.AutomaticEnv()
+//	viper.SetDefault("log.level", "info")
+//	viper.GetString("db.host") // APP_DB_HOST, optional
+//	viper.BindEnv("db.user")   // APP_DB_USER, required
+//
+// A read may as well be satisfied by a config file or flag, so only keys
+// bound with BindEnv or MustBindEnv are required. Setup is collected from
+// the whole file, whether it is done on the package or on an instance from
+// viper.New().
+func (gf *goFile) viperUsages(add func(ast.Node, UsageResult)) {
+	pkg := gf.imports.viper
+	receivers := map[string]bool{pkg: true}
+
+	var prefix string
+	var automatic bool
+	var replacer *strings.Replacer
+	defaults := make(map[string]string)
+	bound := make(map[string]bool)
+
+	// First pass: instances and env setup
+	ast.Inspect(gf.file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, rhs := range node.Rhs {
+				if call, ok := rhs.(*ast.CallExpr); ok && calleeName(call.Fun) == pkg+".New" && i < len(node.Lhs) {
+					if ident, ok := node.Lhs[i].(*ast.Ident); ok {
+						receivers[ident.Name] = true
+					}
+				}
+			}
+		case *ast.CallExpr:
+			method, ok := viperMethod(node, receivers)
+			if !ok {
+				return true
+			}
+			switch method {
+			case "SetEnvPrefix":
+				if len(node.Args) == 1 {
+					prefix, _ = gf.resolveString(node.Args[0])
+				}
+			case "AutomaticEnv":
+				automatic = true
+			case "SetEnvKeyReplacer":
+				if len(node.Args) == 1 {
+					replacer = gf.stringsReplacer(node.Args[0])
+				}
+			case "SetDefault":
+				if len(node.Args) == 2 {
+					if key, ok := gf.resolveString(node.Args[0]); ok {
+						defaults[strings.ToLower(key)] = gf.exprValue(node.Args[1])
+					}
+				}
+			case "BindEnv", "MustBindEnv":
+				if len(node.Args) > 0 {
+					if key, ok := gf.resolveString(node.Args[0]); ok {
+						bound[strings.ToLower(key)] = true
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	envName := func(key string) string {
+		name := strings.ToUpper(key)
+		if prefix != "" {
+			name = strings.ToUpper(prefix + "_" + key)
+		}
+		if replacer != nil {
+			name = replacer.Replace(name)
+		}
+		return name
+	}
+
+	// Second pass: reads and bindings
+	ast.Inspect(gf.file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		method, ok := viperMethod(call, receivers)
+		if !ok {
+			return true
+		}
+		key, ok := gf.resolveString(call.Args[0])
+		if !ok || key == "" {
+			return true
+		}
+
+		usage := UsageResult{
+			Pattern:     "Go viper " + method,
+			Confidence:  0.9,
+			Requirement: RequirementRequired,
+		}
+		if def, ok := defaults[strings.ToLower(key)]; ok {
+			usage.Requirement = RequirementOptional
+			usage.Default = def
+		}
+
+		// Keys with dots or dashes and no replacer for them can't be set
+		// from the environment
+		addDerived := func() {
+			usage.Variable = envName(key)
+			if envNamePattern.MatchString(usage.Variable) {
+				add(call, usage)
+			}
+		}
+
+		isBind := method == "BindEnv" || method == "MustBindEnv"
+		switch {
+		case isBind && len(call.Args) > 1:
+			// Explicit names are used as-is, without the prefix
+			for _, arg := range call.Args[1:] {
+				if name, ok := gf.resolveString(arg); ok && name != "" {
+					usage.Variable = name
+					usage.Confidence = 1.0
+					add(call, usage)
+				}
+			}
+		case isBind:
+			addDerived()
+		case bound[strings.ToLower(key)]:
+			// Reported where the key is bound
+		case automatic && method == "IsSet":
+			usage.Requirement = RequirementExistence
+			usage.Default = ""
+			addDerived()
+		case automatic && strings.HasPrefix(method, "Get") && method != "GetViper":
+			if usage.Requirement == RequirementRequired {
+				usage.Requirement = RequirementOptional
+			}
+			usage.Confidence = 0.7
+			addDerived()
+		}
+		return true
+	})
+}
+
+// viperMethod returns the method name of a call on the viper package or a
+// viper instance
+func viperMethod(call *ast.CallExpr, receivers map[string]bool) (string, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+	x, ok := sel.X.(*ast.Ident)
+	if !ok || !receivers[x.Name] {
+		return "", false
+	}
+	return sel.Sel.Name, true
+}
+
+// stringsReplacer evaluates strings.NewReplacer("old", "new", ...) with
+// literal arguments
+func (gf *goFile) stringsReplacer(expr ast.Expr) *strings.Replacer {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || !strings.HasSuffix(calleeName(call.Fun), ".NewReplacer") || len(call.Args)%2 != 0 {
+		return nil
+	}
+	var pairs []string
+	for _, arg := range call.Args {
+		s, ok := gf.resolveString(arg)
+		if !ok {
+			return nil
+		}
+		pairs = append(pairs, s)
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// structTypes returns the struct types declared in the file by name
+func (gf *goFile) structTypes() map[string]*ast.StructType {
+	structs := make(map[string]*ast.StructType)
+	ast.Inspect(gf.file, func(n ast.Node) bool {
+		if spec, ok := n.(*ast.TypeSpec); ok {
+			if st, ok := spec.Type.(*ast.StructType); ok {
+				structs[spec.Name.Name] = st
+			}
+		}
+		return true
+	})
+	return structs
+}
+
+// typeOf returns the struct type name of a Process/Parse argument such as
+// &cfg, &Config{}, cfg or new(Config), looking up how local variables were
+// declared
+func (gf *goFile) typeOf(expr ast.Expr) string {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		return exprTypeName(e.Type)
+	case *ast.CallExpr:
+		if calleeName(e.Fun) == "new" && len(e.Args) == 1 {
+			return exprTypeName(e.Args[0])
+		}
+	case *ast.Ident:
+		var found string
+		ast.Inspect(gf.file, func(n ast.Node) bool {
+			if found != "" {
+				return false
+			}
+			switch node := n.(type) {
+			case *ast.ValueSpec:
+				for i, name := range node.Names {
+					if name.Name != e.Name {
+						continue
+					}
+					if node.Type != nil {
+						found = exprTypeName(node.Type)
+					} else if i < len(node.Values) {
+						found = gf.typeOf(node.Values[i])
+					}
+				}
+			case *ast.AssignStmt:
+				for i, lhs := range node.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok && ident.Name == e.Name && i < len(node.Rhs) && len(node.Lhs) == len(node.Rhs) {
+						found = gf.typeOf(node.Rhs[i])
+					}
+				}
+			case *ast.Field:
+				for _, name := range node.Names {
+					if name.Name == e.Name {
+						found = exprTypeName(node.Type)
+					}
+				}
+			}
+			return true
+		})
+		return found
+	}
+	return ""
+}
+
+// exprTypeName returns T for T, *T and pkg.T
+func exprTypeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return exprTypeName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	}
+	return ""
+}
+
+// structOf returns the struct a field type refers to, if declared in the file
+func structOf(structs map[string]*ast.StructType, expr ast.Expr) *ast.StructType {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch t := expr.(type) {
+	case *ast.StructType:
+		return t
+	case *ast.Ident:
+		return structs[t.Name]
+	}
+	return nil
+}
+
+type rootStruct struct {
+	st       *ast.StructType
+	prefixes []string
+}
+
+// rootStructs returns the structs to walk for a tag: those passed to the
+// library in this file, with their prefixes, and tagged structs that are not
+// nested in another tagged struct
+func rootStructs(structs map[string]*ast.StructType, callSites map[string][]string, tagKey string) []rootStruct {
+	nested := make(map[*ast.StructType]bool)
+	for _, st := range structs {
+		if !hasTag(st, tagKey) {
+			continue
+		}
+		for _, field := range st.Fields.List {
+			if inner := structOf(structs, field.Type); inner != nil {
+				nested[inner] = true
+			}
+		}
+	}
+
+	// Sort names so usages come out in a stable order
+	names := make([]string, 0, len(structs))
+	for name := range structs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var roots []rootStruct
+	for _, name := range names {
+		st := structs[name]
+		if prefixes, ok := callSites[name]; ok {
+			roots = append(roots, rootStruct{st: st, prefixes: prefixes})
+		} else if hasTag(st, tagKey) && !nested[st] {
+			roots = append(roots, rootStruct{st: st, prefixes: []string{""}})
+		}
+	}
+	return roots
+}
+
+func hasTaggedStruct(structs map[string]*ast.StructType, key string) bool {
+	for _, st := range structs {
+		if hasTag(st, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTag(st *ast.StructType, key string) bool {
+	for _, field := range st.Fields.List {
+		if _, ok := fieldTag(field).Lookup(key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func fieldTag(field *ast.Field) reflect.StructTag {
+	if field.Tag == nil {
+		return ""
+	}
+	tag, _ := strconv.Unquote(field.Tag.Value)
+	return reflect.StructTag(tag)
+}
+
+// fieldNames returns the names of a field, or its type name when embedded
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) == 0 {
+		if name := exprTypeName(field.Type); name != "" {
+			return []string{name}
+		}
+		return nil
+	}
+	names := make([]string, len(field.Names))
+	for i, name := range field.Names {
+		names[i] = name.Name
+	}
+	return names
+}
+
+var (
+	gatherWords = regexp.MustCompile("([^A-Z]+|[A-Z]+[^A-Z]+|[A-Z]+)")
+	acronym     = regexp.MustCompile("([A-Z]+)([A-Z][^A-Z]+)")
+)
+
+// splitWords splits a field name the way envconfig's split_words does,
+// e.g. "MaxDBConns" -> "Max_DB_Conns"
+func splitWords(name string) string {
+	var words []string
+	for _, word := range gatherWords.FindAllString(name, -1) {
+		if m := acronym.FindStringSubmatch(word); len(m) == 3 {
+			words = append(words, m[1], m[2])
+		} else {
+			words = append(words, word)
+		}
+	}
+	return strings.Join(words, "_")
+}
+
+// packageName guesses the package name of an import path, skipping major
+// version suffixes such as /v11
+func packageName(path string) string {
+	parts := strings.Split(path, "/")
+	name := parts[len(parts)-1]
+	if len(parts) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = parts[len(parts)-2]
+	}
+	return name
+}
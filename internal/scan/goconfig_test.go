@@ -0,0 +1,174 @@
+package scan
+
+import "testing"
+
+const configSource = `package config
+
+import (
+	"strings"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/kelseyhightower/envconfig"
+	"github.com/spf13/viper"
+)
+
+type Spec struct {
+	Port        int    ` + "`default:\"8080\"`" + `
+	DatabaseURL string ` + "`envconfig:\"DATABASE_URL\" required:\"true\"`" + `
+	MaxDBConns  int    ` + "`split_words:\"true\"`" + `
+	Internal    string ` + "`ignored:\"true\"`" + `
+	Cache       CacheSpec
+}
+
+type CacheSpec struct {
+	TTL string
+}
+
+type Config struct {
+	Token string   ` + "`env:\"API_TOKEN,required\"`" + `
+	Level string   ` + "`env:\"LOG_LEVEL\" envDefault:\"info\"`" + `
+	DB    DBConfig ` + "`envPrefix:\"DB_\"`" + `
+	skip  string
+}
+
+type DBConfig struct {
+	Host string ` + "`env:\"HOST,notEmpty\"`" + `
+}
+
+func Load() {
+	var spec Spec
+	envconfig.MustProcess("app", &spec)
+
+	cfg := Config{}
+	_ = env.ParseWithOptions(&cfg, env.Options{Prefix: "SVC_"})
+
+	v := viper.New()
+	v.SetEnvPrefix("web")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	v.SetDefault("server.timeout", "30s")
+	_ = v.GetString("server.host")
+	_ = v.GetDuration("server.timeout")
+	_ = v.IsSet("feature.beta")
+	_ = v.BindEnv("metrics", "METRICS_ADDR")
+}
+`
+
+func TestScanGoConfigLibraries(t *testing.T) {
+	result, err := NewProjectScanner().scanGoFile("config.go", []byte(configSource), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		variable    string
+		line        int
+		requirement Requirement
+		def         string
+	}{
+		// envconfig, prefix from the Process call site
+		{variable: "APP_PORT", line: 12, requirement: RequirementOptional, def: "8080"},
+		{variable: "APP_DATABASE_URL", line: 13, requirement: RequirementRequired},
+		{variable: "APP_MAX_DB_CONNS", line: 14, requirement: RequirementOptional},
+		{variable: "APP_CACHE_TTL", line: 20, requirement: RequirementOptional},
+		// caarlos0/env, prefix from Options and envPrefix
+		{variable: "SVC_API_TOKEN", line: 24, requirement: RequirementRequired},
+		{variable: "SVC_LOG_LEVEL", line: 25, requirement: RequirementOptional, def: "info"},
+		{variable: "SVC_DB_HOST", line: 31, requirement: RequirementRequired},
+		// viper with AutomaticEnv
+		{variable: "WEB_SERVER_HOST", line: 46, requirement: RequirementOptional},
+		{variable: "WEB_SERVER_TIMEOUT", line: 47, requirement: RequirementOptional, def: "30s"},
+		{variable: "WEB_FEATURE_BETA", line: 48, requirement: RequirementExistence},
+		{variable: "METRICS_ADDR", line: 49, requirement: RequirementRequired},
+	}
+
+	if len(result.Variables) != len(tests) {
+		t.Errorf("found %d variables, want %d: %v", len(result.Variables), len(tests), result.Variables)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.variable, func(t *testing.T) {
+			usages := result.Variables[tt.variable]
+			if len(usages) != 1 {
+				t.Fatalf("got %d usages, want 1", len(usages))
+			}
+
+			u := usages[0]
+			if u.Line != tt.line {
+				t.Errorf("Line = %d, want %d", u.Line, tt.line)
+			}
+			if u.Requirement != tt.requirement {
+				t.Errorf("Requirement = %q, want %q", u.Requirement, tt.requirement)
+			}
+			if u.Default != tt.def {
+				t.Errorf("Default = %q, want %q", u.Default, tt.def)
+			}
+		})
+	}
+}
+
+const viperSource = `package config
+
+import "github.com/spf13/viper"
+
+func Load() {
+	viper.SetEnvPrefix("app")
+	viper.AutomaticEnv()
+	_ = viper.GetString("db.host")
+	viper.MustBindEnv("token")
+	_ = viper.GetString("token")
+	_ = viper.GetInt("workers")
+}
+`
+
+func TestScanGoViper(t *testing.T) {
+	result, err := NewProjectScanner().scanGoFile("config.go", []byte(viperSource), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		variable    string
+		line        int
+		requirement Requirement
+	}{
+		// bound keys are required, where they are bound
+		{variable: "APP_TOKEN", line: 9, requirement: RequirementRequired},
+		// plain reads may come from a config file instead
+		{variable: "APP_WORKERS", line: 11, requirement: RequirementOptional},
+	}
+
+	// APP_DB.HOST can't be set from the environment without a key replacer
+	if len(result.Variables) != len(tests) {
+		t.Errorf("found %d variables, want %d: %v", len(result.Variables), len(tests), result.Variables)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.variable, func(t *testing.T) {
+			usages := result.Variables[tt.variable]
+			if len(usages) != 1 {
+				t.Fatalf("got %d usages, want 1", len(usages))
+			}
+			if usages[0].Line != tt.line {
+				t.Errorf("Line = %d, want %d", usages[0].Line, tt.line)
+			}
+			if usages[0].Requirement != tt.requirement {
+				t.Errorf("Requirement = %q, want %q", usages[0].Requirement, tt.requirement)
+			}
+		})
+	}
+}
+
+func TestSplitWords(t *testing.T) {
+	tests := map[string]string{
+		"Port":       "Port",
+		"MaxDBConns": "Max_DB_Conns",
+		"APIKey":     "API_Key",
+		"HTTPServer": "HTTP_Server",
+	}
+	for in, want := range tests {
+		if got := splitWords(in); got != want {
+			t.Errorf("splitWords(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,503 @@
+package scan
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// goLookup describes a function that reads an environment variable
+type goLookup struct {
+	name        string // pattern name reported in UsageResult
+	keyArg      int    // index of the argument holding the variable name
+	defaultArg  int    // index of the argument holding a fallback value, -1 if none
+	requirement Requirement
+	confidence  float64
+}
+
+// goFile holds what we know about a parsed Go file while scanning it
+type goFile struct {
+	fset     *token.FileSet
+	file     *ast.File
+	lines    []string
+	consts   map[string]string   // string constants by name
+	lookups  map[string]goLookup // call target ("os.Getenv", "getEnv") -> lookup
+	defaults map[*ast.CallExpr]string
+	cmpOr    string // local name of cmp.Or, if the cmp package is imported
+	imports  configImports
+}
+
+// scanGoFile discovers environment variable lookups in Go source using the
+// AST instead of regular expressions. It understands os/syscall Getenv and
+// LookupEnv, string constants used as keys, helper functions wrapping a
+// lookup, fallback values assigned when the variable is empty, and config
+// libraries (envconfig, caarlos0/env struct tags and viper). Constants and
+// helpers may be declared in other files of the package in dir, which may
+// be nil.
+func (ps *ProjectScanner) scanGoFile(filePath string, src []byte, dir *goDir) (*ScanResult, error) {
+	gf, err := parseGoFile(filePath, src)
+	if err != nil {
+		return nil, err
+	}
+	if dir != nil {
+		gf.use(dir.packages[gf.file.Name.Name])
+	}
+	gf.collectConsts()
+	gf.collectStdlibLookups()
+	gf.collectHelpers()
+	gf.collectFallbacks()
+	gf.collectConfigImports()
+
+	result := &ScanResult{
+		Variables: make(map[string][]UsageResult),
+		Files:     []string{filePath},
+		Errors:    []error{},
+	}
+
+	record := func(funcName string) func(ast.Node) bool {
+		return func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			lookup, ok := gf.lookups[calleeName(call.Fun)]
+			if !ok || lookup.keyArg >= len(call.Args) {
+				return true
+			}
+			varName, ok := gf.resolveString(call.Args[lookup.keyArg])
+			if !ok || varName == "" {
+				return true
+			}
+
+			usage := UsageResult{
+				Variable:    varName,
+				File:        filePath,
+				Line:        gf.fset.Position(call.Pos()).Line,
+				Pattern:     lookup.name,
+				Language:    "go",
+				Confidence:  lookup.confidence,
+				Function:    funcName,
+				Requirement: lookup.requirement,
+			}
+			usage.Context = gf.line(usage.Line)
+
+			if lookup.defaultArg >= 0 && lookup.defaultArg < len(call.Args) {
+				usage.Default = gf.exprValue(call.Args[lookup.defaultArg])
+				usage.Requirement = RequirementOptional
+			}
+			if def, ok := gf.defaults[call]; ok {
+				usage.Default = def
+				usage.Requirement = RequirementOptional
+			}
+
+			result.Variables[varName] = append(result.Variables[varName], usage)
+			return true
+		}
+	}
+
+	for _, decl := range gf.file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Body != nil {
+				ast.Inspect(d.Body, record(funcDeclName(d)))
+			}
+		case *ast.GenDecl:
+			// Package-level var initialisers
+			ast.Inspect(d, record(""))
+		}
+	}
+
+	for _, usage := range gf.configUsages(filePath) {
+		result.Variables[usage.Variable] = append(result.Variables[usage.Variable], usage)
+	}
+
+	return result, nil
+}
+
+// collectConsts records string constants declared anywhere in the file
+func (gf *goFile) collectConsts() {
+	// Constants may refer to each other, so resolve until nothing changes
+	for changed := true; changed; {
+		changed = false
+		ast.Inspect(gf.file, func(n ast.Node) bool {
+			decl, ok := n.(*ast.GenDecl)
+			if !ok || decl.Tok != token.CONST {
+				return true
+			}
+			for _, spec := range decl.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, name := range vs.Names {
+					if i >= len(vs.Values) {
+						continue
+					}
+					if _, known := gf.consts[name.Name]; known {
+						continue
+					}
+					if value, ok := gf.resolveString(vs.Values[i]); ok {
+						gf.consts[name.Name] = value
+						changed = true
+					}
+				}
+			}
+			return true
+		})
+	}
+}
+
+// collectStdlibLookups registers os and syscall lookups under their import names
+func (gf *goFile) collectStdlibLookups() {
+	for _, imp := range gf.file.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		local := path
+		if imp.Name != nil {
+			local = imp.Name.Name
+		}
+		if local == "_" || local == "." {
+			continue
+		}
+
+		// cmp.Or(os.Getenv("X"), "default") supplies a fallback
+		if path == "cmp" {
+			gf.cmpOr = local + ".Or"
+		}
+		if path != "os" && path != "syscall" {
+			continue
+		}
+
+		gf.lookups[local+".Getenv"] = goLookup{
+			name: "Go " + path + ".Getenv", defaultArg: -1, requirement: RequirementRequired, confidence: 1.0,
+		}
+		gf.lookups[local+".LookupEnv"] = goLookup{
+			name: "Go " + path + ".LookupEnv", defaultArg: -1, requirement: RequirementExistence, confidence: 1.0,
+		}
+	}
+}
+
+// collectHelpers finds functions in the file that wrap a lookup, such as
+//
+//	func getEnv(key, fallback string) string {
+//		if v := os.Getenv(key); v != "" {
+//			return v
+//		}
+//		return fallback
+//	}
+//
+// so that calls like getEnv("PORT", "8080") are discovered too.
+func (gf *goFile) collectHelpers() {
+	// Helpers may wrap other helpers, so repeat until nothing new is found
+	for changed := true; changed; {
+		changed = false
+		for _, decl := range gf.file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Body == nil {
+				continue
+			}
+			if _, known := gf.lookups[fn.Name.Name]; known {
+				continue
+			}
+
+			params := paramNames(fn.Type.Params)
+			if len(params) == 0 {
+				continue
+			}
+
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				inner, ok := gf.lookups[calleeName(call.Fun)]
+				if !ok || inner.keyArg >= len(call.Args) {
+					return true
+				}
+				ident, ok := call.Args[inner.keyArg].(*ast.Ident)
+				if !ok {
+					return true
+				}
+				keyArg := indexOf(params, ident.Name)
+				if keyArg < 0 {
+					return true
+				}
+
+				helper := goLookup{
+					name:        "Go env helper " + fn.Name.Name,
+					keyArg:      keyArg,
+					defaultArg:  returnedParam(fn, params, keyArg),
+					requirement: RequirementRequired,
+					confidence:  0.9,
+				}
+
+				// Wrapping a fallback helper only makes this one optional if a
+				// fallback is actually passed through, e.g. not getEnv(key, "")
+				switch {
+				case inner.defaultArg >= 0 && inner.defaultArg < len(call.Args):
+					if ident, ok := call.Args[inner.defaultArg].(*ast.Ident); ok && indexOf(params, ident.Name) >= 0 {
+						helper.defaultArg = indexOf(params, ident.Name)
+					} else if value, ok := gf.resolveString(call.Args[inner.defaultArg]); !ok || value != "" {
+						helper.requirement = RequirementOptional
+					}
+				case inner.defaultArg < 0 && inner.requirement == RequirementExistence:
+					// A helper around LookupEnv only checks for existence if it
+					// hands the ok result on; otherwise it enforces the value,
+					// as in must-style helpers that exit when it's unset
+					if returnsBool(fn) {
+						helper.requirement = RequirementExistence
+					}
+				case inner.defaultArg < 0:
+					helper.requirement = inner.requirement
+				}
+				if helper.defaultArg >= 0 {
+					helper.requirement = RequirementOptional
+				}
+				gf.lookups[fn.Name.Name] = helper
+				changed = true
+				return false
+			})
+		}
+	}
+}
+
+// collectFallbacks finds defaults applied after a lookup, e.g.
+//
+//	port := os.Getenv("PORT")
+//	if port == "" {
+//		port = "8080"
+//	}
+func (gf *goFile) collectFallbacks() {
+	ast.Inspect(gf.file, func(n ast.Node) bool {
+		block, ok := n.(*ast.BlockStmt)
+		if !ok {
+			return true
+		}
+
+		// variable name -> lookup call that assigned it
+		assigned := make(map[string]*ast.CallExpr)
+		// "ok" result of LookupEnv -> value variable name
+		found := make(map[string]string)
+
+		for _, stmt := range block.List {
+			switch s := stmt.(type) {
+			case *ast.AssignStmt:
+				if len(s.Rhs) != 1 {
+					continue
+				}
+				call, ok := s.Rhs[0].(*ast.CallExpr)
+				if !ok {
+					continue
+				}
+				if _, ok := gf.lookups[calleeName(call.Fun)]; !ok {
+					continue
+				}
+				if ident, ok := s.Lhs[0].(*ast.Ident); ok {
+					assigned[ident.Name] = call
+					if len(s.Lhs) == 2 {
+						if okIdent, ok := s.Lhs[1].(*ast.Ident); ok {
+							found[okIdent.Name] = ident.Name
+						}
+					}
+				}
+
+			case *ast.IfStmt:
+				name := gf.emptyCheck(s.Cond, found)
+				call, ok := assigned[name]
+				if name == "" || !ok {
+					continue
+				}
+				if def, ok := assignedValue(s.Body, name); ok {
+					gf.defaults[call] = gf.exprValue(def)
+				}
+			}
+		}
+
+		return true
+	})
+
+	if gf.cmpOr == "" {
+		return
+	}
+
+	// cmp.Or(os.Getenv("X"), "default")
+	ast.Inspect(gf.file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || calleeName(call.Fun) != gf.cmpOr || len(call.Args) < 2 {
+			return true
+		}
+		if inner, ok := call.Args[0].(*ast.CallExpr); ok {
+			gf.defaults[inner] = gf.exprValue(call.Args[len(call.Args)-1])
+		}
+		return true
+	})
+}
+
+// emptyCheck returns the variable tested by `v == ""`, `"" == v`, `len(v) == 0`
+// or `!ok` (where ok came from LookupEnv), or "" if cond is none of those
+func (gf *goFile) emptyCheck(cond ast.Expr, found map[string]string) string {
+	switch c := cond.(type) {
+	case *ast.UnaryExpr:
+		if ident, ok := c.X.(*ast.Ident); ok && c.Op == token.NOT {
+			return found[ident.Name]
+		}
+	case *ast.BinaryExpr:
+		if c.Op != token.EQL {
+			return ""
+		}
+		for _, pair := range [][2]ast.Expr{{c.X, c.Y}, {c.Y, c.X}} {
+			value, isString := gf.resolveString(pair[1])
+			isZero := (isString && value == "") || isZeroLiteral(pair[1])
+			if !isZero {
+				continue
+			}
+			if ident, ok := pair[0].(*ast.Ident); ok {
+				return ident.Name
+			}
+			if call, ok := pair[0].(*ast.CallExpr); ok && calleeName(call.Fun) == "len" && len(call.Args) == 1 {
+				if ident, ok := call.Args[0].(*ast.Ident); ok {
+					return ident.Name
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// resolveString evaluates string literals, constants and their concatenation
+func (gf *goFile) resolveString(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(e.Value)
+		return value, err == nil
+	case *ast.Ident:
+		value, ok := gf.consts[e.Name]
+		return value, ok
+	case *ast.ParenExpr:
+		return gf.resolveString(e.X)
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		left, ok := gf.resolveString(e.X)
+		if !ok {
+			return "", false
+		}
+		right, ok := gf.resolveString(e.Y)
+		return left + right, ok
+	}
+	return "", false
+}
+
+// exprValue returns a default value as a string when it can be resolved,
+// otherwise the source of the expression
+func (gf *goFile) exprValue(expr ast.Expr) string {
+	if value, ok := gf.resolveString(expr); ok {
+		return value
+	}
+	return types.ExprString(expr)
+}
+
+func (gf *goFile) line(n int) string {
+	if n < 1 || n > len(gf.lines) {
+		return ""
+	}
+	return strings.TrimSpace(gf.lines[n-1])
+}
+
+// calleeName returns "pkg.Func" or "Func" for a call target
+func calleeName(fun ast.Expr) string {
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return f.Name
+	case *ast.SelectorExpr:
+		if x, ok := f.X.(*ast.Ident); ok {
+			return x.Name + "." + f.Sel.Name
+		}
+	}
+	return ""
+}
+
+// funcDeclName returns the function name, including the receiver for methods
+func funcDeclName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	return "(" + types.ExprString(fn.Recv.List[0].Type) + ")." + fn.Name.Name
+}
+
+func paramNames(fields *ast.FieldList) []string {
+	var names []string
+	for _, field := range fields.List {
+		if len(field.Names) == 0 {
+			names = append(names, "_")
+			continue
+		}
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+	return names
+}
+
+// returnedParam returns the index of a parameter, other than the key, that
+// the function returns directly (its fallback), or -1
+func returnedParam(fn *ast.FuncDecl, params []string, keyArg int) int {
+	index := -1
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		ret, ok := n.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) == 0 {
+			return true
+		}
+		if ident, ok := ret.Results[0].(*ast.Ident); ok {
+			if i := indexOf(params, ident.Name); i >= 0 && i != keyArg {
+				index = i
+			}
+		}
+		return true
+	})
+	return index
+}
+
+// returnsBool reports whether a function has a bool result, such as the
+// ok of a LookupEnv wrapper
+func returnsBool(fn *ast.FuncDecl) bool {
+	if fn.Type.Results == nil {
+		return false
+	}
+	for _, field := range fn.Type.Results.List {
+		if ident, ok := field.Type.(*ast.Ident); ok && ident.Name == "bool" {
+			return true
+		}
+	}
+	return false
+}
+
+// assignedValue returns the value assigned to name in a block
+func assignedValue(block *ast.BlockStmt, name string) (ast.Expr, bool) {
+	for _, stmt := range block.List {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			continue
+		}
+		if ident, ok := assign.Lhs[0].(*ast.Ident); ok && ident.Name == name {
+			return assign.Rhs[0], true
+		}
+	}
+	return nil, false
+}
+
+func isZeroLiteral(expr ast.Expr) bool {
+	lit, ok := expr.(*ast.BasicLit)
+	return ok && lit.Kind == token.INT && lit.Value == "0"
+}
+
+func indexOf(list []string, s string) int {
+	for i, item := range list {
+		if item == s {
+			return i
+		}
+	}
+	return -1
+}
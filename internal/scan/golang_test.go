@@ -0,0 +1,220 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+const goSource = `package main
+
+import (
+	"cmp"
+	env "os"
+	"syscall"
+)
+
+const (
+	prefix  = "APP_"
+	portKey = prefix + "PORT"
+)
+
+var dsn = env.Getenv("DATABASE_URL")
+
+func getEnv(key, fallback string) string {
+	if v := env.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func mustEnv(key string) string {
+	v := getEnv(key, "")
+	if v == "" {
+		panic(key)
+	}
+	return v
+}
+
+type Config struct{}
+
+func (c *Config) Load() {
+	port := env.Getenv(portKey)
+	if port == "" {
+		port = "8080"
+	}
+	token, ok := env.LookupEnv("API_TOKEN")
+	if !ok {
+		token = "none"
+	}
+	_, _ = token, syscall.Getenv("HOME_DIR")
+	_ = cmp.Or(env.Getenv("LOG_LEVEL"), "info")
+	_ = getEnv("TIMEOUT", "30s")
+	_ = mustEnv("JWT_SECRET")
+	_ = env.Getenv(someFunc())
+	_, _ = env.LookupEnv("FEATURE_FLAG")
+}
+
+func mustLookup(key string) string {
+	v, ok := env.LookupEnv(key)
+	if !ok {
+		panic(key)
+	}
+	return v
+}
+
+func lookup(key string) (string, bool) {
+	return env.LookupEnv(key)
+}
+
+func (c *Config) LoadSecrets() {
+	_ = mustLookup("SIGNING_KEY")
+	_, _ = lookup("DEBUG_TOKEN")
+}
+`
+
+func TestScanGoFile(t *testing.T) {
+	result, err := NewProjectScanner().scanGoFile("main.go", []byte(goSource), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		variable    string
+		line        int
+		function    string
+		requirement Requirement
+		def         string
+	}{
+		{variable: "DATABASE_URL", line: 14, function: "", requirement: RequirementRequired},
+		{variable: "APP_PORT", line: 34, function: "(*Config).Load", requirement: RequirementOptional, def: "8080"},
+		{variable: "API_TOKEN", line: 38, function: "(*Config).Load", requirement: RequirementOptional, def: "none"},
+		{variable: "HOME_DIR", line: 42, function: "(*Config).Load", requirement: RequirementRequired},
+		{variable: "LOG_LEVEL", line: 43, function: "(*Config).Load", requirement: RequirementOptional, def: "info"},
+		{variable: "TIMEOUT", line: 44, function: "(*Config).Load", requirement: RequirementOptional, def: "30s"},
+		{variable: "JWT_SECRET", line: 45, function: "(*Config).Load", requirement: RequirementRequired},
+		{variable: "FEATURE_FLAG", line: 47, function: "(*Config).Load", requirement: RequirementExistence},
+		{variable: "SIGNING_KEY", line: 63, function: "(*Config).LoadSecrets", requirement: RequirementRequired},
+		{variable: "DEBUG_TOKEN", line: 64, function: "(*Config).LoadSecrets", requirement: RequirementExistence},
+	}
+
+	if len(result.Variables) != len(tests) {
+		t.Errorf("found %d variables, want %d: %v", len(result.Variables), len(tests), result.Variables)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.variable, func(t *testing.T) {
+			usages := result.Variables[tt.variable]
+			if len(usages) != 1 {
+				t.Fatalf("got %d usages, want 1", len(usages))
+			}
+
+			u := usages[0]
+			if u.Line != tt.line {
+				t.Errorf("Line = %d, want %d", u.Line, tt.line)
+			}
+			if u.Function != tt.function {
+				t.Errorf("Function = %q, want %q", u.Function, tt.function)
+			}
+			if u.Requirement != tt.requirement {
+				t.Errorf("Requirement = %q, want %q", u.Requirement, tt.requirement)
+			}
+			if u.Default != tt.def {
+				t.Errorf("Default = %q, want %q", u.Default, tt.def)
+			}
+		})
+	}
+}
+
+func TestScanGoFileFallsBackToPatterns(t *testing.T) {
+	src := "package main\n\nfunc main() { os.Getenv(\"BROKEN_VAR\") \n"
+	result, err := NewProjectScanner().scanFile("broken.go", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Variables["BROKEN_VAR"]) == 0 {
+		t.Error("expected BROKEN_VAR to be found by the regex fallback")
+	}
+}
+
+func TestScanGoPackage(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"config.go": `package main
+
+import "os"
+
+const dbKey = "DB_HOST"
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+`,
+		"main.go": `package main
+
+import "example.com/app/config"
+
+func main() {
+	_ = getEnv(dbKey, "localhost")
+	_ = getEnv("LOG_LEVEL", "")
+	_ = config.Get("API_TOKEN")
+}
+`,
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	custom := VariablePattern{
+		Name:     "config.Get",
+		Pattern:  regexp.MustCompile(`config\.Get\("([A-Z_]+)"\)`),
+		Language: "go",
+	}
+	result, err := NewProjectScanner().WithCustomPatterns([]VariablePattern{custom}).ScanProject(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		variable    string
+		line        int
+		requirement Requirement
+		def         string
+	}{
+		// helper and constant declared in config.go
+		{variable: "DB_HOST", line: 6, requirement: RequirementOptional, def: "localhost"},
+		{variable: "LOG_LEVEL", line: 7, requirement: RequirementOptional},
+		// another package, only known to the custom pattern
+		{variable: "API_TOKEN", line: 8, requirement: RequirementRequired},
+	}
+
+	if len(result.Variables) != len(tests) {
+		t.Errorf("found %d variables, want %d: %v", len(result.Variables), len(tests), result.Variables)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.variable, func(t *testing.T) {
+			usages := result.Variables[tt.variable]
+			if len(usages) != 1 {
+				t.Fatalf("got %d usages, want 1", len(usages))
+			}
+
+			u := usages[0]
+			if filepath.Base(u.File) != "main.go" || u.Line != tt.line {
+				t.Errorf("found at %s:%d, want main.go:%d", u.File, u.Line, tt.line)
+			}
+			if u.Requirement != tt.requirement {
+				t.Errorf("Requirement = %q, want %q", u.Requirement, tt.requirement)
+			}
+			if u.Default != tt.def {
+				t.Errorf("Default = %q, want %q", u.Default, tt.def)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package scan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// goPackage holds what the files of a Go package declare for each other:
+// string constants and helper functions wrapping a lookup. A helper defined
+// in config.go is called from main.go without any import.
+type goPackage struct {
+	consts  map[string]string
+	helpers map[string]goLookup
+}
+
+// goDir holds the packages of the Go files in one directory, usually a
+// package and its external _test package
+type goDir struct {
+	once     sync.Once
+	packages map[string]*goPackage // by package name
+	digest   string                // identifies the tables, for the scan cache
+}
+
+// goDir returns the packages declared in dir, parsing its Go files the
+// first time it is asked for during a scan
+func (ps *ProjectScanner) goDir(dir string) *goDir {
+	ps.goDirsMu.Lock()
+	if ps.goDirs == nil {
+		ps.goDirs = make(map[string]*goDir)
+	}
+	gd, ok := ps.goDirs[dir]
+	if !ok {
+		gd = &goDir{}
+		ps.goDirs[dir] = gd
+	}
+	ps.goDirsMu.Unlock()
+
+	gd.once.Do(func() { gd.load(ps, dir) })
+	return gd
+}
+
+// load parses every Go file in dir and collects the constants and helpers
+// of each package, until helpers wrapping helpers in other files are found
+func (gd *goDir) load(ps *ProjectScanner, dir string) {
+	gd.packages = make(map[string]*goPackage)
+
+	files := make(map[string][]*goFile)
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".go" {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		src, skipped, err := ps.readFile(path)
+		if err != nil || skipped != nil {
+			continue
+		}
+		gf, err := parseGoFile(path, src)
+		if err != nil {
+			continue
+		}
+		gf.collectStdlibLookups()
+		files[gf.file.Name.Name] = append(files[gf.file.Name.Name], gf)
+	}
+
+	for name, pkgFiles := range files {
+		pkg := &goPackage{
+			consts:  make(map[string]string),
+			helpers: make(map[string]goLookup),
+		}
+		for changed := true; changed; {
+			changed = false
+			for _, gf := range pkgFiles {
+				gf.use(pkg)
+				gf.collectConsts()
+				gf.collectHelpers()
+				for name, value := range gf.consts {
+					if _, known := pkg.consts[name]; !known {
+						pkg.consts[name] = value
+						changed = true
+					}
+				}
+				for name, lookup := range gf.lookups {
+					if _, known := pkg.helpers[name]; !known && !strings.Contains(name, ".") {
+						pkg.helpers[name] = lookup
+						changed = true
+					}
+				}
+			}
+		}
+		gd.packages[name] = pkg
+	}
+
+	gd.digest = gd.hash()
+}
+
+// hash summarises the tables, so cached results of a file are dropped when
+// a constant or helper in another file of its package changes
+func (gd *goDir) hash() string {
+	var lines []string
+	for name, pkg := range gd.packages {
+		for constName, value := range pkg.consts {
+			lines = append(lines, fmt.Sprintf("%s const %s %q", name, constName, value))
+		}
+		for helperName, lookup := range pkg.helpers {
+			lines = append(lines, fmt.Sprintf("%s func %s %+v", name, helperName, lookup))
+		}
+	}
+	sort.Strings(lines)
+
+	h := sha256.New()
+	for _, line := range lines {
+		fmt.Fprintln(h, line)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// parseGoFile parses Go source into a goFile with empty tables
+func parseGoFile(filePath string, src []byte) (*goFile, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, src, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &goFile{
+		fset:     fset,
+		file:     file,
+		lines:    strings.Split(string(src), "\n"),
+		consts:   make(map[string]string),
+		lookups:  make(map[string]goLookup),
+		defaults: make(map[*ast.CallExpr]string),
+	}, nil
+}
+
+// use adds the constants and helpers declared elsewhere in the package,
+// keeping the file's own
+func (gf *goFile) use(pkg *goPackage) {
+	if pkg == nil {
+		return
+	}
+	for name, value := range pkg.consts {
+		if _, known := gf.consts[name]; !known {
+			gf.consts[name] = value
+		}
+	}
+	for name, lookup := range pkg.helpers {
+		if _, known := gf.lookups[name]; !known {
+			gf.lookups[name] = lookup
+		}
+	}
+}
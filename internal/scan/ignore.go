@@ -0,0 +1,206 @@
+package scan
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IgnoreFiles are read from every directory during a scan, in this order,
+// so .ecolintignore can re-include (!pattern) what .gitignore excludes
+var IgnoreFiles = []string{".gitignore", ".ecolintignore"}
+
+// globRule is a single gitignore-style pattern
+type globRule struct {
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// globRules is an ordered list of patterns where the last match wins
+type globRules []globRule
+
+// compileGlobs compiles gitignore-style patterns:
+//
+//   - "bin" or "*.min.js" (no slash) matches a name at any depth
+//   - "/build" or "docs/*.md" (with a slash) is relative to the base directory
+//   - "dist/" only matches directories
+//   - "**" matches any number of directories, "*" and "?" stay within one
+//   - "!pattern" re-includes what an earlier pattern matched
+//
+// Blank lines and lines starting with # are skipped.
+func compileGlobs(patterns []string) globRules {
+	var rules globRules
+	for _, pattern := range patterns {
+		if rule, ok := compileGlob(pattern); ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+func compileGlob(pattern string) (globRule, bool) {
+	var rule globRule
+
+	pattern = strings.TrimRight(pattern, " \t\r")
+	if pattern == "" || strings.HasPrefix(pattern, "#") {
+		return rule, false
+	}
+	if strings.HasPrefix(pattern, "!") {
+		rule.negate = true
+		pattern = pattern[1:]
+	} else if strings.HasPrefix(pattern, `\`) {
+		pattern = pattern[1:] // \# and \! escape a literal first character
+	}
+	if strings.HasSuffix(pattern, "/") {
+		rule.dirOnly = true
+		pattern = strings.TrimRight(pattern, "/")
+	}
+	if pattern == "" {
+		return rule, false
+	}
+
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	expr := globToRegexp(pattern)
+	if anchored {
+		expr = "^" + expr + "$"
+	} else {
+		expr = "(?:^|/)" + expr + "$"
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return rule, false
+	}
+	rule.re = re
+	return rule, true
+}
+
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// match reports whether rel (slash-separated, relative to the rules' base
+// directory) is matched, and whether any rule applied at all
+func (rules globRules) match(rel string, isDir bool) (matched, decided bool) {
+	for _, rule := range rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if rule.re.MatchString(rel) {
+			matched, decided = !rule.negate, true
+		}
+	}
+	return matched, decided
+}
+
+// ignoreTree holds the ignore files found while walking, by directory
+type ignoreTree struct {
+	root  string
+	rules map[string]globRules // directory -> rules from its ignore files
+}
+
+func newIgnoreTree(root string) *ignoreTree {
+	return &ignoreTree{root: root, rules: make(map[string]globRules)}
+}
+
+// load reads the ignore files in dir, if any
+func (t *ignoreTree) load(dir string) {
+	var rules globRules
+	for _, name := range IgnoreFiles {
+		rules = append(rules, compileGlobs(readLines(filepath.Join(dir, name)))...)
+	}
+	if len(rules) > 0 {
+		t.rules[dir] = rules
+	}
+}
+
+// ignored reports whether the ignore files of the directories above p
+// exclude it. Deeper files override shallower ones.
+func (t *ignoreTree) ignored(p string, isDir bool) bool {
+	var dirs []string
+	for dir := filepath.Dir(p); ; dir = filepath.Dir(dir) {
+		dirs = append(dirs, dir)
+		if dir == t.root || dir == "." || dir == filepath.Dir(dir) {
+			break
+		}
+	}
+
+	ignored := false
+	for i := len(dirs) - 1; i >= 0; i-- {
+		rules, ok := t.rules[dirs[i]]
+		if !ok {
+			continue
+		}
+		rel, err := filepath.Rel(dirs[i], p)
+		if err != nil {
+			continue
+		}
+		if matched, decided := rules.match(filepath.ToSlash(rel), isDir); decided {
+			ignored = matched
+		}
+	}
+	return ignored
+}
+
+func readLines(file string) []string {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
+
+// relSlash returns p relative to root with forward slashes
+func relSlash(root, p string) string {
+	rel, err := filepath.Rel(root, p)
+	if err != nil {
+		return filepath.ToSlash(p)
+	}
+	return path.Clean(filepath.ToSlash(rel))
+}
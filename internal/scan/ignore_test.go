@@ -0,0 +1,101 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestGlobRules(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		path     string
+		isDir    bool
+		want     bool
+	}{
+		{patterns: []string{"bin"}, path: "bin", isDir: true, want: true},
+		{patterns: []string{"bin"}, path: "tools/bin", isDir: true, want: true},
+		{patterns: []string{"bin"}, path: "cabinet", isDir: true, want: false},
+		{patterns: []string{"*.min.js"}, path: "web/app.min.js", want: true},
+		{patterns: []string{"*.min.js"}, path: "web/app.js", want: false},
+		{patterns: []string{"/build"}, path: "build", isDir: true, want: true},
+		{patterns: []string{"/build"}, path: "src/build", isDir: true, want: false},
+		{patterns: []string{"dist/"}, path: "dist", isDir: true, want: true},
+		{patterns: []string{"dist/"}, path: "dist", isDir: false, want: false},
+		{patterns: []string{"docs/**/*.md"}, path: "docs/a/b/c.md", want: true},
+		{patterns: []string{"docs/**/*.md"}, path: "docs/c.md", want: true},
+		{patterns: []string{"**/*.go"}, path: "main.go", want: true},
+		{patterns: []string{"**/*.go"}, path: "cmd/x/main.go", want: true},
+		{patterns: []string{"deploy/*.yaml"}, path: "deploy/k8s/api.yaml", want: false},
+		{patterns: []string{"file?.txt"}, path: "file1.txt", want: true},
+		{patterns: []string{"[ab].env"}, path: "a.env", want: true},
+		{patterns: []string{"[!ab].env"}, path: "a.env", want: false},
+		{patterns: []string{"*.log", "!keep.log"}, path: "keep.log", want: false},
+		{patterns: []string{"# comment", "", `\#notes`}, path: "#notes", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.patterns, ",")+" "+tt.path, func(t *testing.T) {
+			got, _ := compileGlobs(tt.patterns).match(tt.path, tt.isDir)
+			if got != tt.want {
+				t.Errorf("match(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanProjectExcludesAndIgnoreFiles(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"cabinet/app.js":         "process.env.CABINET_VAR",
+		"bin/tool.js":            "process.env.BIN_VAR",
+		"generated/api.js":       "process.env.GENERATED_VAR",
+		"web/app.min.js":         "process.env.MINIFIED_VAR",
+		"web/app.js":             "process.env.WEB_VAR",
+		"web/.gitignore":         "local/\n*.local.js\n",
+		"web/local/dev.js":       "process.env.LOCAL_DIR_VAR",
+		"web/dev.local.js":       "process.env.LOCAL_FILE_VAR",
+		"web/sub/.gitignore":     "!*.local.js\n",
+		"web/sub/keep.local.js":  "process.env.REINCLUDED_VAR",
+		".gitignore":             "generated/\n",
+		".ecolintignore":         "*.min.js\n",
+		"scripts/deploy.sh":      "echo $DEPLOY_VAR",
+		"scripts/.ecolintignore": "deploy.sh\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := NewProjectScanner().ScanProject(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for name := range result.Variables {
+		got = append(got, name)
+	}
+	sort.Strings(got)
+
+	want := []string{"CABINET_VAR", "REINCLUDED_VAR", "WEB_VAR"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("variables = %v, want %v", got, want)
+	}
+
+	// Include globs replace the extension list
+	result, err = NewProjectScanner().WithIncludePatterns([]string{"web/*.js"}).ScanProject(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Variables) != 1 || result.Variables["WEB_VAR"] == nil {
+		t.Errorf("with include globs, variables = %v, want only WEB_VAR", result.Variables)
+	}
+}
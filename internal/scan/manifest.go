@@ -0,0 +1,416 @@
+package scan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Languages reported for variables declared by deployment manifests
+const (
+	LanguageKubernetes = "kubernetes"
+	LanguageCompose    = "docker-compose"
+	LanguageHelm       = "helm"
+)
+
+// envFromRef is a Kubernetes envFrom entry, resolved against the ConfigMaps
+// and Secrets found anywhere in the project once scanning is done
+type envFromRef struct {
+	source   string // "ConfigMap/name" or "Secret/name"
+	prefix   string
+	manifest string
+}
+
+// helmEnvKeys are values keys that conventionally hold container env
+var helmEnvKeys = map[string]bool{
+	"env": true, "extraEnv": true, "envVars": true, "extraEnvVars": true, "environment": true,
+}
+
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// scanManifest parses YAML files that are Kubernetes manifests, docker-compose
+// files or Helm values files and records the variables they set. Other YAML
+// files, and documents that don't parse (such as Helm templates), are ignored.
+func (ps *ProjectScanner) scanManifest(filePath string, src []byte, result *ScanResult) error {
+	lines := strings.Split(string(src), "\n")
+	helm := isHelmValues(filePath)
+
+	for _, doc := range splitDocuments(lines) {
+		var root yaml.MapSlice
+		if err := yaml.Unmarshal([]byte(strings.Join(doc.lines, "\n")), &root); err != nil || root == nil {
+			continue
+		}
+
+		m := &manifestFile{
+			path:   filePath,
+			result: result,
+			finder: &lineFinder{lines: doc.lines, offset: doc.offset},
+		}
+
+		switch {
+		case helm:
+			m.helmValues(root, "")
+		case isKubernetes(root):
+			m.kubernetes(root)
+		case isCompose(filePath, root):
+			m.compose(root)
+		}
+	}
+
+	return nil
+}
+
+type manifestFile struct {
+	path   string
+	result *ScanResult
+	finder *lineFinder
+}
+
+func (m *manifestFile) declare(name, language, pattern, manifest string) {
+	if !envNamePattern.MatchString(name) {
+		return
+	}
+	line := m.finder.find(name)
+	usage := UsageResult{
+		Variable:    name,
+		File:        m.path,
+		Line:        line,
+		Context:     m.finder.context(line),
+		Pattern:     pattern,
+		Language:    language,
+		Confidence:  1.0,
+		Requirement: RequirementDeclared,
+		Manifest:    manifest,
+	}
+	m.result.Variables[name] = append(m.result.Variables[name], usage)
+}
+
+// kubernetes handles a single Kubernetes object. ConfigMaps and Secrets are
+// remembered for envFrom; anything else is searched for containers.
+func (m *manifestFile) kubernetes(root yaml.MapSlice) {
+	kind := asString(mapGet(root, "kind"))
+	metadata, _ := mapGet(root, "metadata").(yaml.MapSlice)
+	object := kind + "/" + asString(mapGet(metadata, "name"))
+
+	if kind == "ConfigMap" || kind == "Secret" {
+		if m.result.configData == nil {
+			m.result.configData = make(map[string][]UsageResult)
+		}
+		for _, key := range []string{"data", "stringData"} {
+			data, _ := mapGet(root, key).(yaml.MapSlice)
+			for _, item := range data {
+				name := asString(item.Key)
+				line := m.finder.find(name)
+				m.result.configData[object] = append(m.result.configData[object], UsageResult{
+					Variable: name,
+					File:     m.path,
+					Line:     line,
+					Context:  m.finder.context(line),
+				})
+			}
+		}
+		return
+	}
+
+	walkYAML(root, func(key string, value interface{}) {
+		if key != "containers" && key != "initContainers" {
+			return
+		}
+		containers, _ := value.([]interface{})
+		for _, c := range containers {
+			container, ok := c.(yaml.MapSlice)
+			if !ok {
+				continue
+			}
+			manifest := object
+			if name := asString(mapGet(container, "name")); name != "" {
+				manifest += " (" + name + ")"
+			}
+
+			env, _ := mapGet(container, "env").([]interface{})
+			for _, e := range env {
+				if entry, ok := e.(yaml.MapSlice); ok {
+					m.declare(asString(mapGet(entry, "name")), LanguageKubernetes, "Kubernetes env", manifest)
+				}
+			}
+
+			envFrom, _ := mapGet(container, "envFrom").([]interface{})
+			for _, e := range envFrom {
+				entry, ok := e.(yaml.MapSlice)
+				if !ok {
+					continue
+				}
+				for _, source := range [][2]string{{"configMapRef", "ConfigMap"}, {"secretRef", "Secret"}} {
+					if ref, ok := mapGet(entry, source[0]).(yaml.MapSlice); ok {
+						m.result.envFrom = append(m.result.envFrom, envFromRef{
+							source:   source[1] + "/" + asString(mapGet(ref, "name")),
+							prefix:   asString(mapGet(entry, "prefix")),
+							manifest: manifest,
+						})
+					}
+				}
+			}
+		}
+	})
+}
+
+// compose handles environment: and env_file: of each docker-compose service
+func (m *manifestFile) compose(root yaml.MapSlice) {
+	services, _ := mapGet(root, "services").(yaml.MapSlice)
+	for _, item := range services {
+		service, ok := item.Value.(yaml.MapSlice)
+		if !ok {
+			continue
+		}
+		manifest := "service " + asString(item.Key)
+
+		switch env := mapGet(service, "environment").(type) {
+		case yaml.MapSlice:
+			for _, e := range env {
+				m.declare(asString(e.Key), LanguageCompose, "Compose environment", manifest)
+			}
+		case []interface{}:
+			for _, e := range env {
+				name, _, _ := strings.Cut(asString(e), "=")
+				m.declare(strings.TrimSpace(name), LanguageCompose, "Compose environment", manifest)
+			}
+		}
+
+		// env_file: .env, a list of paths, or a list of {path: ...}
+		var envFiles []interface{}
+		switch files := mapGet(service, "env_file").(type) {
+		case string:
+			envFiles = []interface{}{files}
+		case []interface{}:
+			envFiles = files
+		}
+		for _, f := range envFiles {
+			path := asString(f)
+			if entry, ok := f.(yaml.MapSlice); ok {
+				path = asString(mapGet(entry, "path"))
+			}
+			if path != "" {
+				m.result.EnvFiles = append(m.result.EnvFiles, filepath.Join(filepath.Dir(m.path), path))
+			}
+		}
+	}
+}
+
+// helmValues finds env maps and lists anywhere in a values file, e.g.
+//
+//	api:
+//	  env:
+//	    LOG_LEVEL: info
+//	  extraEnv:
+//	    - name: FEATURE_FLAGS
+//	      value: beta
+func (m *manifestFile) helmValues(node yaml.MapSlice, path string) {
+	for _, item := range node {
+		key := asString(item.Key)
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+
+		if helmEnvKeys[key] {
+			manifest := "values " + keyPath
+			switch env := item.Value.(type) {
+			case yaml.MapSlice:
+				for _, e := range env {
+					// Lower-case keys are more likely nested settings than variables
+					if name := asString(e.Key); strings.ToUpper(name) == name {
+						m.declare(name, LanguageHelm, "Helm values env", manifest)
+					}
+				}
+				continue
+			case []interface{}:
+				for _, e := range env {
+					if entry, ok := e.(yaml.MapSlice); ok {
+						m.declare(asString(mapGet(entry, "name")), LanguageHelm, "Helm values env", manifest)
+					}
+				}
+				continue
+			}
+		}
+
+		if child, ok := item.Value.(yaml.MapSlice); ok {
+			m.helmValues(child, keyPath)
+		}
+	}
+}
+
+// resolveEnvFrom adds the keys of ConfigMaps and Secrets referenced through
+// envFrom, once every file has been scanned
+func (sr *ScanResult) resolveEnvFrom() {
+	for _, ref := range sr.envFrom {
+		for _, data := range sr.configData[ref.source] {
+			usage := data
+			usage.Variable = ref.prefix + data.Variable
+			usage.Pattern = "Kubernetes envFrom"
+			usage.Language = LanguageKubernetes
+			usage.Confidence = 1.0
+			usage.Requirement = RequirementDeclared
+			usage.Manifest = ref.manifest + " via " + ref.source
+			if envNamePattern.MatchString(usage.Variable) {
+				sr.Variables[usage.Variable] = append(sr.Variables[usage.Variable], usage)
+			}
+		}
+	}
+	sr.envFrom = nil
+}
+
+// DeclaredVariables returns the variables set by deployment manifests, with
+// the usages that declare them
+func (sr *ScanResult) DeclaredVariables() map[string][]UsageResult {
+	declared := make(map[string][]UsageResult)
+	for name, usages := range sr.Variables {
+		for _, usage := range usages {
+			if usage.Manifest != "" {
+				declared[name] = append(declared[name], usage)
+			}
+		}
+	}
+	return declared
+}
+
+func isKubernetes(root yaml.MapSlice) bool {
+	return asString(mapGet(root, "apiVersion")) != "" && asString(mapGet(root, "kind")) != ""
+}
+
+func isCompose(filePath string, root yaml.MapSlice) bool {
+	services, ok := mapGet(root, "services").(yaml.MapSlice)
+	if !ok {
+		return false
+	}
+	if strings.Contains(strings.ToLower(filepath.Base(filePath)), "compose") {
+		return true
+	}
+	for _, item := range services {
+		if service, ok := item.Value.(yaml.MapSlice); ok {
+			if mapGet(service, "image") != nil || mapGet(service, "build") != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isHelmValues reports whether a file is values.yaml (or values-*.yaml)
+// next to a Chart.yaml
+func isHelmValues(filePath string) bool {
+	base := strings.ToLower(filepath.Base(filePath))
+	if !strings.HasPrefix(base, "values") {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(filepath.Dir(filePath), "Chart.yaml"))
+	return err == nil
+}
+
+// walkYAML calls fn for every key in a decoded YAML tree
+func walkYAML(node interface{}, fn func(key string, value interface{})) {
+	switch n := node.(type) {
+	case yaml.MapSlice:
+		for _, item := range n {
+			fn(asString(item.Key), item.Value)
+			walkYAML(item.Value, fn)
+		}
+	case []interface{}:
+		for _, item := range n {
+			walkYAML(item, fn)
+		}
+	}
+}
+
+func mapGet(m yaml.MapSlice, key string) interface{} {
+	for _, item := range m {
+		if asString(item.Key) == key {
+			return item.Value
+		}
+	}
+	return nil
+}
+
+func asString(v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case yaml.MapSlice, []interface{}:
+		return ""
+	default:
+		return fmt.Sprint(s)
+	}
+}
+
+type yamlDocument struct {
+	lines  []string
+	offset int // lines before the document in the file
+}
+
+// splitDocuments splits a multi-document YAML file on --- separators
+func splitDocuments(lines []string) []yamlDocument {
+	var docs []yamlDocument
+	start := 0
+	for i, line := range lines {
+		if strings.HasPrefix(line, "---") {
+			docs = append(docs, yamlDocument{lines: lines[start:i], offset: start})
+			start = i + 1
+		}
+	}
+	return append(docs, yamlDocument{lines: lines[start:], offset: start})
+}
+
+// lineFinder locates decoded keys in the source, since yaml.v2 doesn't
+// report positions. Keys are searched in document order from the last match.
+type lineFinder struct {
+	lines  []string
+	offset int
+	cursor int
+}
+
+// find returns the 1-based file line of the next occurrence of name, or the
+// first line of the document if it can't be found
+func (lf *lineFinder) find(name string) int {
+	for _, from := range []int{lf.cursor, 0} {
+		for i := from; i < len(lf.lines); i++ {
+			if containsWord(lf.lines[i], name) {
+				lf.cursor = i
+				return lf.offset + i + 1
+			}
+		}
+	}
+	return lf.offset + 1
+}
+
+func (lf *lineFinder) context(line int) string {
+	i := line - lf.offset - 1
+	if i < 0 || i >= len(lf.lines) {
+		return ""
+	}
+	return strings.TrimSpace(lf.lines[i])
+}
+
+// containsWord reports whether name appears in line delimited by
+// non-identifier characters
+func containsWord(line, name string) bool {
+	for i := 0; ; {
+		j := strings.Index(line[i:], name)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(name)
+		if (start == 0 || !isIdentChar(line[start-1])) && (end == len(line) || !isIdentChar(line[end])) {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
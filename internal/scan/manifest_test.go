@@ -0,0 +1,135 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var manifestFixtures = map[string]string{
+	"k8s/api.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: api-config
+data:
+  LOG_LEVEL: info
+  CACHE_TTL: "60"
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+spec:
+  template:
+    spec:
+      containers:
+        - name: web
+          env:
+            - name: DATABASE_URL
+              valueFrom:
+                secretKeyRef:
+                  name: db
+                  key: url
+            - name: PORT
+              value: "8080"
+          envFrom:
+            - configMapRef:
+                name: api-config
+              prefix: APP_
+`,
+	"docker-compose.yml": `services:
+  worker:
+    image: worker:latest
+    env_file: .env.worker
+    environment:
+      - QUEUE_URL=redis://queue
+      - DEBUG
+  web:
+    build: .
+    environment:
+      SESSION_SECRET: dev
+`,
+	"chart/Chart.yaml": "name: api\n",
+	"chart/values.yaml": `replicaCount: 1
+api:
+  env:
+    FEATURE_FLAGS: beta
+    nested: {}
+  extraEnv:
+    - name: SENTRY_DSN
+      value: ""
+`,
+	// Not a manifest: keys must not be picked up
+	"config/settings.yaml": "env:\n  SHOULD_NOT_APPEAR: 1\n",
+}
+
+func TestScanManifests(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range manifestFixtures {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := NewProjectScanner().ScanProject(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		variable string
+		file     string
+		line     int
+		language string
+		manifest string
+	}{
+		{variable: "DATABASE_URL", file: "k8s/api.yaml", line: 19, language: LanguageKubernetes, manifest: "Deployment/api (web)"},
+		{variable: "PORT", file: "k8s/api.yaml", line: 24, language: LanguageKubernetes, manifest: "Deployment/api (web)"},
+		{variable: "APP_LOG_LEVEL", file: "k8s/api.yaml", line: 6, language: LanguageKubernetes, manifest: "Deployment/api (web) via ConfigMap/api-config"},
+		{variable: "APP_CACHE_TTL", file: "k8s/api.yaml", line: 7, language: LanguageKubernetes, manifest: "Deployment/api (web) via ConfigMap/api-config"},
+		{variable: "QUEUE_URL", file: "docker-compose.yml", line: 6, language: LanguageCompose, manifest: "service worker"},
+		{variable: "DEBUG", file: "docker-compose.yml", line: 7, language: LanguageCompose, manifest: "service worker"},
+		{variable: "SESSION_SECRET", file: "docker-compose.yml", line: 11, language: LanguageCompose, manifest: "service web"},
+		{variable: "FEATURE_FLAGS", file: "chart/values.yaml", line: 4, language: LanguageHelm, manifest: "values api.env"},
+		{variable: "SENTRY_DSN", file: "chart/values.yaml", line: 7, language: LanguageHelm, manifest: "values api.extraEnv"},
+	}
+
+	declared := result.DeclaredVariables()
+	if len(declared) != len(tests) {
+		t.Errorf("found %d declared variables, want %d: %v", len(declared), len(tests), declared)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.variable, func(t *testing.T) {
+			usages := declared[tt.variable]
+			if len(usages) != 1 {
+				t.Fatalf("got %d declarations, want 1", len(usages))
+			}
+
+			u := usages[0]
+			if u.File != filepath.Join(root, tt.file) || u.Line != tt.line {
+				t.Errorf("location = %s:%d, want %s:%d", u.File, u.Line, tt.file, tt.line)
+			}
+			if u.Language != tt.language {
+				t.Errorf("Language = %q, want %q", u.Language, tt.language)
+			}
+			if u.Manifest != tt.manifest {
+				t.Errorf("Manifest = %q, want %q", u.Manifest, tt.manifest)
+			}
+			if u.Requirement != RequirementDeclared {
+				t.Errorf("Requirement = %q, want %q", u.Requirement, RequirementDeclared)
+			}
+		})
+	}
+
+	if len(result.EnvFiles) != 1 || result.EnvFiles[0] != filepath.Join(root, ".env.worker") {
+		t.Errorf("EnvFiles = %v, want [.env.worker]", result.EnvFiles)
+	}
+	if got := result.GetRequiredVariables(0, 1); len(got) != 0 {
+		t.Errorf("declared variables should not be required, got %v", got)
+	}
+}
@@ -2,18 +2,31 @@ package scan
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 )
 
 // ProjectScanner discovers environment variable usage across a project
 type ProjectScanner struct {
 	patterns     []VariablePattern
-	excludePaths []string
+	custom       []VariablePattern // also matched in Go files that parse
+	excludePaths []string          // gitignore-style patterns
 	includeExts  []string
+	includeGlobs []string // when set, replaces includeExts
+	concurrency  int      // files scanned in parallel, 0 means GOMAXPROCS
+	cache        *Cache
+	skip         SkipOptions
+
+	goDirsMu sync.Mutex
+	goDirs   map[string]*goDir // Go packages by directory, for one scan
 }
 
 type VariablePattern struct {
@@ -21,21 +34,58 @@ type VariablePattern struct {
 	Pattern     *regexp.Regexp
 	Description string
 	Language    string
+	// Classify decides how a match depends on the variable. It receives the
+	// submatches and the text before and after the match on the line; nil
+	// uses classifyUsage, which recognises common fallback operators.
+	Classify func(match []string, before, after string) (Requirement, string)
 }
 
+// Requirement describes how code depends on a variable
+type Requirement string
+
+const (
+	RequirementRequired  Requirement = "required"  // used without a fallback
+	RequirementOptional  Requirement = "optional"  // a default is supplied when unset
+	RequirementExistence Requirement = "existence" // only checked for being set
+	RequirementDeclared  Requirement = "declared"  // set by a deployment manifest, not read by code
+)
+
 type UsageResult struct {
-	Variable   string
-	File       string
-	Line       int
-	Context    string
-	Pattern    string
-	Confidence float64 // 0.0 - 1.0 confidence this is actually an env var
+	Variable    string      `json:"variable"`
+	File        string      `json:"file"`
+	Line        int         `json:"line"`
+	Context     string      `json:"context"`
+	Pattern     string      `json:"pattern"`
+	Language    string      `json:"language"`
+	Confidence  float64     `json:"confidence"`            // 0.0 - 1.0 confidence this is actually an env var
+	Function    string      `json:"function,omitempty"`    // enclosing function, when the scanner knows it
+	Requirement Requirement `json:"requirement,omitempty"` // empty when the pattern cannot tell
+	Default     string      `json:"default,omitempty"`     // fallback value used when unset, if visible
+	Manifest    string      `json:"manifest,omitempty"`    // deployment object declaring the variable, e.g. "Deployment/api (web)"
 }
 
 type ScanResult struct {
 	Variables map[string][]UsageResult // var name -> usages
 	Files     []string                 // files scanned
 	Errors    []error                  // scanning errors
+	EnvFiles  []string                 // env files loaded by manifests, e.g. compose env_file
+	Cached    int                      // files whose results came from the cache
+	Skipped   []SkippedFile            // matching files that were not scanned
+
+	envFrom    []envFromRef             // resolved once all files are scanned
+	configData map[string][]UsageResult // ConfigMap/Secret keys by "Kind/name"
+}
+
+// VariableSummary aggregates the usages of a single variable
+type VariableSummary struct {
+	Name       string   `json:"name"`
+	Confidence float64  `json:"confidence"` // average over all usages
+	Usages     int      `json:"usages"`
+	Files      []string `json:"files"`
+	Languages  []string `json:"languages"`
+	// Requirement is the strictest requirement over all usages
+	Requirement Requirement `json:"requirement"`
+	Defaults    []string    `json:"defaults,omitempty"`
 }
 
 // NewProjectScanner creates a scanner with common environment variable patterns
@@ -44,7 +94,7 @@ func NewProjectScanner() *ProjectScanner {
 		patterns: getCommonPatterns(),
 		excludePaths: []string{
 			"node_modules", "vendor", "dist", "build", ".git",
-			"target", "bin", "obj", ".next", ".nuxt",
+			"target", "bin", "obj", ".next", ".nuxt", CacheDir,
 		},
 		includeExts: []string{
 			".go", ".js", ".ts", ".jsx", ".tsx", ".py", ".java",
@@ -53,6 +103,7 @@ func NewProjectScanner() *ProjectScanner {
 			".yml", ".yaml", ".json", ".toml", ".ini", ".conf",
 			".dockerfile", "Dockerfile", ".env", ".env.example",
 		},
+		skip: DefaultSkipOptions(),
 	}
 	return scanner
 }
@@ -60,85 +111,298 @@ func NewProjectScanner() *ProjectScanner {
 // WithCustomPatterns adds custom regex patterns for finding env vars
 func (ps *ProjectScanner) WithCustomPatterns(patterns []VariablePattern) *ProjectScanner {
 	ps.patterns = append(ps.patterns, patterns...)
+	ps.custom = append(ps.custom, patterns...)
 	return ps
 }
 
-// WithExcludePaths sets directories to skip during scanning
+// WithConcurrency sets how many files are scanned in parallel. Zero or
+// less uses one worker per CPU.
+func (ps *ProjectScanner) WithConcurrency(n int) *ProjectScanner {
+	ps.concurrency = n
+	return ps
+}
+
+// WithCache reuses results for files whose content hasn't changed since
+// the cache was saved. The cache is saved after every complete scan.
+func (ps *ProjectScanner) WithCache(cache *Cache) *ProjectScanner {
+	ps.cache = cache
+	return ps
+}
+
+// WithSkipOptions sets which binary, minified and oversized files are
+// skipped rather than scanned
+func (ps *ProjectScanner) WithSkipOptions(opts SkipOptions) *ProjectScanner {
+	ps.skip = opts
+	return ps
+}
+
+// WithExcludePaths sets gitignore-style patterns for paths to skip during
+// scanning, e.g. "vendor", "*.min.js", "/build/" or "docs/**/*.md"
 func (ps *ProjectScanner) WithExcludePaths(paths []string) *ProjectScanner {
 	ps.excludePaths = paths
 	return ps
 }
 
+// WithIncludePatterns restricts scanning to files matching at least one
+// glob, e.g. "**/*.go" or "deploy/*.yaml". When set, file extensions are not
+// consulted.
+func (ps *ProjectScanner) WithIncludePatterns(globs []string) *ProjectScanner {
+	ps.includeGlobs = globs
+	return ps
+}
+
 // WithIncludeExtensions sets file extensions to scan
 func (ps *ProjectScanner) WithIncludeExtensions(exts []string) *ProjectScanner {
 	ps.includeExts = exts
 	return ps
 }
 
+// GetExcludePaths returns the directories skipped during scanning
+func (ps *ProjectScanner) GetExcludePaths() []string {
+	return ps.excludePaths
+}
+
+// GetIncludePatterns returns the globs restricting which files are scanned
+func (ps *ProjectScanner) GetIncludePatterns() []string {
+	return ps.includeGlobs
+}
+
+// GetIncludeExtensions returns the file extensions that are scanned
+func (ps *ProjectScanner) GetIncludeExtensions() []string {
+	return ps.includeExts
+}
+
 // ScanProject scans the entire project for environment variable usage
 func (ps *ProjectScanner) ScanProject(rootPath string) (*ScanResult, error) {
+	return ps.ScanProjectContext(context.Background(), rootPath)
+}
+
+// ScanProjectContext scans the project with a pool of workers. Files are
+// merged in walk order, so the result is the same whichever worker finishes
+// first. If ctx is cancelled the files scanned so far are returned along
+// with ctx.Err().
+func (ps *ProjectScanner) ScanProjectContext(ctx context.Context, rootPath string) (*ScanResult, error) {
 	result := &ScanResult{
 		Variables: make(map[string][]UsageResult),
 		Files:     []string{},
 		Errors:    []error{},
 	}
 
+	rootPath = filepath.Clean(rootPath)
+	ps.goDirsMu.Lock()
+	ps.goDirs = nil
+	ps.goDirsMu.Unlock()
+	if ps.cache != nil {
+		ps.cache.use(ps.cacheKey())
+	}
+	excludes := compileGlobs(ps.excludePaths)
+	includes := compileGlobs(ps.includeGlobs)
+	ignores := newIgnoreTree(rootPath)
+
+	// Walk first: it is cheap next to scanning and fixes the merge order
+	var files []string
 	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
 		if err != nil {
 			result.Errors = append(result.Errors, fmt.Errorf("error accessing %s: %w", path, err))
 			return nil // Continue walking
 		}
 
-		// Skip directories we don't want to scan
-		if info.IsDir() {
-			for _, exclude := range ps.excludePaths {
-				if strings.Contains(path, exclude) {
+		// Skip excluded and ignored paths, and everything below them
+		rel := relSlash(rootPath, path)
+		if path != rootPath {
+			excluded, _ := excludes.match(rel, info.IsDir())
+			if excluded || ignores.ignored(path, info.IsDir()) {
+				if info.IsDir() {
 					return filepath.SkipDir
 				}
+				return nil
 			}
-			return nil
 		}
 
-		// Skip files with extensions we don't care about
-		if !ps.shouldScanFile(path) {
+		if info.IsDir() {
+			ignores.load(path)
 			return nil
 		}
 
-		// Scan the file
-		fileResult, err := ps.scanFile(path)
-		if err != nil {
-			result.Errors = append(result.Errors, fmt.Errorf("error scanning %s: %w", path, err))
-			return nil
+		// Skip files we don't care about
+		if ps.shouldScanFile(path, rel, includes) {
+			files = append(files, path)
 		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
 
-		result.Files = append(result.Files, path)
+	type scanned struct {
+		result *ScanResult
+		cached bool
+		err    error
+		done   bool
+	}
+	results := make([]scanned, len(files))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < ps.workers(len(files)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fileResult, cached, err := ps.scanCached(files[i])
+				results[i] = scanned{result: fileResult, cached: cached, err: err, done: true}
+			}
+		}()
+	}
 
-		// Merge results
-		for varName, usages := range fileResult.Variables {
-			result.Variables[varName] = append(result.Variables[varName], usages...)
+feed:
+	for i := range files {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
 		}
+	}
+	close(jobs)
+	wg.Wait()
 
-		return nil
-	})
+	// Merge in walk order
+	for i, file := range results {
+		if !file.done {
+			continue
+		}
+		if file.err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("error scanning %s: %w", files[i], file.err))
+			continue
+		}
+		result.merge(files[i], file.result)
+		if file.cached {
+			result.Cached++
+		}
+	}
+	result.resolveEnvFrom()
 
-	return result, err
+	if ctx.Err() != nil {
+		return result, ctx.Err()
+	}
+
+	if ps.cache != nil {
+		if err := ps.cache.Save(); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("error saving scan cache: %w", err))
+		}
+	}
+
+	return result, nil
 }
 
-// ScanFile scans a single file for environment variable usage
-func (ps *ProjectScanner) scanFile(filePath string) (*ScanResult, error) {
-	file, err := os.Open(filePath)
+// scanCached scans a file unless it is skipped, reusing the cached result
+// if it is unchanged
+func (ps *ProjectScanner) scanCached(path string) (*ScanResult, bool, error) {
+	data, skipped, err := ps.readFile(path)
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+	if skipped != nil {
+		return &ScanResult{
+			Variables: make(map[string][]UsageResult),
+			Errors:    []error{},
+			Skipped:   []SkippedFile{*skipped},
+		}, false, nil
+	}
+
+	if ps.cache == nil {
+		result, err := ps.scanFile(path, data)
+		return result, false, err
+	}
+
+	hash := hashContent(data)
+	// Whether values.yaml is a Helm chart's depends on a sibling
+	// Chart.yaml, so that is part of the key as well as the content
+	if isHelmValues(path) {
+		hash += "+helm"
+	}
+	// A Go file's result depends on the constants and helpers of its package
+	if filepath.Ext(path) == ".go" {
+		hash += "+" + ps.goDir(filepath.Dir(path)).digest
+	}
+	if result, ok := ps.cache.lookup(path, hash); ok {
+		return result, true, nil
+	}
+
+	result, err := ps.scanFile(path, data)
+	if err == nil {
+		ps.cache.store(path, hash, result)
+	}
+	return result, false, err
+}
+
+// workers returns how many goroutines to scan n files with
+func (ps *ProjectScanner) workers(n int) int {
+	workers := ps.concurrency
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
 	}
-	defer file.Close()
+	return workers
+}
 
+// merge adds the result of scanning a single file
+func (sr *ScanResult) merge(path string, fileResult *ScanResult) {
+	if len(fileResult.Skipped) > 0 {
+		sr.Skipped = append(sr.Skipped, fileResult.Skipped...)
+		return
+	}
+	sr.Files = append(sr.Files, path)
+
+	for varName, usages := range fileResult.Variables {
+		sr.Variables[varName] = append(sr.Variables[varName], usages...)
+	}
+	sr.Errors = append(sr.Errors, fileResult.Errors...)
+	sr.EnvFiles = append(sr.EnvFiles, fileResult.EnvFiles...)
+	sr.envFrom = append(sr.envFrom, fileResult.envFrom...)
+	for source, data := range fileResult.configData {
+		if sr.configData == nil {
+			sr.configData = make(map[string][]UsageResult)
+		}
+		sr.configData[source] = append(sr.configData[source], data...)
+	}
+}
+
+// scanFile scans the contents of a single file, already read by readFile,
+// for environment variable usage
+func (ps *ProjectScanner) scanFile(filePath string, src []byte) (*ScanResult, error) {
 	result := &ScanResult{
 		Variables: make(map[string][]UsageResult),
 		Files:     []string{filePath},
 		Errors:    []error{},
 	}
 
-	scanner := bufio.NewScanner(file)
+	// Go sources are parsed; fall back to patterns if the file doesn't parse.
+	// Custom patterns still run, for lookups through other packages such as
+	// config.Get("X").
+	patterns := ps.patterns
+	parsed := make(map[string]bool) // variable and line found in the AST
+	if filepath.Ext(filePath) == ".go" {
+		if goResult, err := ps.scanGoFile(filePath, src, ps.goDir(filepath.Dir(filePath))); err == nil {
+			result = goResult
+			patterns = ps.custom
+			for varName, usages := range result.Variables {
+				for _, usage := range usages {
+					parsed[fmt.Sprintf("%s:%d", varName, usage.Line)] = true
+				}
+			}
+		}
+	}
+
+	// Long lines are fine; without a larger buffer the scanner gives up on
+	// the rest of the file at the first line over 64 KiB
+	scanner := bufio.NewScanner(bytes.NewReader(src))
+	scanner.Buffer(make([]byte, 0, 64*1024), ps.lineLimit())
 	lineNum := 0
 
 	for scanner.Scan() {
@@ -146,23 +410,36 @@ func (ps *ProjectScanner) scanFile(filePath string) (*ScanResult, error) {
 		line := scanner.Text()
 
 		// Apply all patterns to this line
-		for _, pattern := range ps.patterns {
-			matches := pattern.Pattern.FindAllStringSubmatch(line, -1)
-			for _, match := range matches {
-				if len(match) < 2 {
+		for _, pattern := range patterns {
+			locs := pattern.Pattern.FindAllStringSubmatchIndex(line, -1)
+			for _, loc := range locs {
+				if len(loc) < 4 || loc[2] < 0 {
 					continue
 				}
 
+				match := submatches(line, loc)
 				varName := match[1] // First capture group should be the variable name
+				if parsed[fmt.Sprintf("%s:%d", varName, lineNum)] {
+					continue
+				}
 				confidence := ps.calculateConfidence(varName, line, pattern)
 
+				classify := pattern.Classify
+				if classify == nil {
+					classify = classifyUsage
+				}
+				requirement, def := classify(match, line[:loc[0]], line[loc[1]:])
+
 				usage := UsageResult{
-					Variable:   varName,
-					File:       filePath,
-					Line:       lineNum,
-					Context:    strings.TrimSpace(line),
-					Pattern:    pattern.Name,
-					Confidence: confidence,
+					Variable:    varName,
+					File:        filePath,
+					Line:        lineNum,
+					Context:     strings.TrimSpace(line),
+					Pattern:     pattern.Name,
+					Language:    pattern.Language,
+					Confidence:  confidence,
+					Requirement: requirement,
+					Default:     def,
 				}
 
 				result.Variables[varName] = append(result.Variables[varName], usage)
@@ -174,13 +451,33 @@ func (ps *ProjectScanner) scanFile(filePath string) (*ScanResult, error) {
 		result.Errors = append(result.Errors, err)
 	}
 
+	// Deployment manifests declare variables as structure, not expressions
+	if ext := filepath.Ext(filePath); ext == ".yml" || ext == ".yaml" {
+		if err := ps.scanManifest(filePath, src, result); err != nil {
+			result.Errors = append(result.Errors, err)
+		}
+	}
+
 	return result, nil
 }
 
 // GetRequiredVariables returns a list of likely required environment variables
-// based on confidence scores and usage frequency
+// based on confidence scores and usage frequency. Variables that are only read
+// with a default or checked for existence are not required.
 func (sr *ScanResult) GetRequiredVariables(minConfidence float64, minUsages int) []string {
 	var required []string
+	for _, varName := range sr.GetDiscoveredVariables(minConfidence, minUsages) {
+		if sr.Summarize(varName).Requirement == RequirementRequired {
+			required = append(required, varName)
+		}
+	}
+	return required
+}
+
+// GetDiscoveredVariables returns every variable meeting the confidence and
+// usage thresholds, however it is used
+func (sr *ScanResult) GetDiscoveredVariables(minConfidence float64, minUsages int) []string {
+	var discovered []string
 
 	for varName, usages := range sr.Variables {
 		if len(usages) < minUsages {
@@ -195,15 +492,74 @@ func (sr *ScanResult) GetRequiredVariables(minConfidence float64, minUsages int)
 		avgConfidence := totalConfidence / float64(len(usages))
 
 		if avgConfidence >= minConfidence {
-			required = append(required, varName)
+			discovered = append(discovered, varName)
 		}
 	}
 
-	return required
+	return discovered
 }
 
-// shouldScanFile determines if a file should be scanned based on extension
-func (ps *ProjectScanner) shouldScanFile(path string) bool {
+// Summarize aggregates the usages of a variable
+func (sr *ScanResult) Summarize(varName string) VariableSummary {
+	usages := sr.Variables[varName]
+	summary := VariableSummary{
+		Name:   varName,
+		Usages: len(usages),
+	}
+
+	fileSet := make(map[string]bool)
+	langSet := make(map[string]bool)
+	defaultSet := make(map[string]bool)
+	totalConfidence := 0.0
+	for _, usage := range usages {
+		totalConfidence += usage.Confidence
+		summary.Requirement = strictest(summary.Requirement, usage.Requirement)
+		if usage.Default != "" && !defaultSet[usage.Default] {
+			defaultSet[usage.Default] = true
+			summary.Defaults = append(summary.Defaults, usage.Default)
+		}
+		if !fileSet[usage.File] {
+			fileSet[usage.File] = true
+			summary.Files = append(summary.Files, usage.File)
+		}
+		if !langSet[usage.Language] {
+			langSet[usage.Language] = true
+			summary.Languages = append(summary.Languages, usage.Language)
+		}
+	}
+
+	if len(usages) > 0 {
+		summary.Confidence = totalConfidence / float64(len(usages))
+	}
+	if summary.Requirement == "" {
+		// No usage says how the variable is read, so assume it is needed
+		summary.Requirement = RequirementRequired
+	}
+	sort.Strings(summary.Files)
+	sort.Strings(summary.Languages)
+
+	return summary
+}
+
+// submatches expands FindStringSubmatchIndex output into strings
+func submatches(line string, loc []int) []string {
+	match := make([]string, len(loc)/2)
+	for i := range match {
+		if loc[2*i] >= 0 {
+			match[i] = line[loc[2*i]:loc[2*i+1]]
+		}
+	}
+	return match
+}
+
+// shouldScanFile determines if a file should be scanned based on the
+// include globs, or its extension when there are none
+func (ps *ProjectScanner) shouldScanFile(path, rel string, includes globRules) bool {
+	if len(includes) > 0 {
+		matched, _ := includes.match(rel, false)
+		return matched
+	}
+
 	ext := filepath.Ext(path)
 	fileName := filepath.Base(path)
 
@@ -294,9 +650,24 @@ func getCommonPatterns() []VariablePattern {
 		},
 		{
 			Name:        "Python os.getenv",
-			Pattern:     regexp.MustCompile(`os\.getenv\(["']([A-Z][A-Z0-9_]*)["']\)`),
+			Pattern:     regexp.MustCompile(`os\.getenv\(["']([A-Z][A-Z0-9_]*)["']`),
 			Description: "Python os.getenv() calls",
 			Language:    "python",
+			Classify:    classifyDefaultArgument,
+		},
+		{
+			Name:        "Python os.environ.get",
+			Pattern:     regexp.MustCompile(`os\.environ\.get\(["']([A-Z][A-Z0-9_]*)["']`),
+			Description: "Python os.environ.get() calls",
+			Language:    "python",
+			Classify:    classifyDefaultArgument,
+		},
+		{
+			Name:        "Python os.environ membership",
+			Pattern:     regexp.MustCompile(`["']([A-Z][A-Z0-9_]*)["']\s+(?:not\s+)?in\s+os\.environ\b`),
+			Description: "Python \"VAR\" in os.environ checks",
+			Language:    "python",
+			Classify:    classifyExistence,
 		},
 		{
 			Name:        "Shell variable expansion",
@@ -304,6 +675,13 @@ func getCommonPatterns() []VariablePattern {
 			Description: "Shell ${VAR} expansion",
 			Language:    "shell",
 		},
+		{
+			Name:        "Shell parameter expansion",
+			Pattern:     regexp.MustCompile(`\$\{([A-Z][A-Z0-9_]*)(:?[-=?+])([^}]*)\}`),
+			Description: "Shell ${VAR:-default}, ${VAR:?} and ${VAR:+alt} expansion",
+			Language:    "shell",
+			Classify:    classifyShellExpansion,
+		},
 		{
 			Name:        "Shell variable simple",
 			Pattern:     regexp.MustCompile(`\$([A-Z][A-Z0-9_]{2,})`),
@@ -328,12 +706,33 @@ func getCommonPatterns() []VariablePattern {
 			Description: "C# Environment.GetEnvironmentVariable() calls",
 			Language:    "csharp",
 		},
+		{
+			Name:        "Node.js process.env membership",
+			Pattern:     regexp.MustCompile(`["']([A-Z][A-Z0-9_]*)["']\s+in\s+process\.env\b`),
+			Description: "Node.js \"VAR\" in process.env checks",
+			Language:    "javascript",
+			Classify:    classifyExistence,
+		},
 		{
 			Name:        "Ruby ENV",
 			Pattern:     regexp.MustCompile(`ENV\[["']([A-Z][A-Z0-9_]*)["']\]`),
 			Description: "Ruby ENV hash access",
 			Language:    "ruby",
 		},
+		{
+			Name:        "Ruby ENV.fetch",
+			Pattern:     regexp.MustCompile(`ENV\.fetch\(["']([A-Z][A-Z0-9_]*)["']`),
+			Description: "Ruby ENV.fetch() calls",
+			Language:    "ruby",
+			Classify:    classifyRubyFetch,
+		},
+		{
+			Name:        "Ruby ENV.key?",
+			Pattern:     regexp.MustCompile(`ENV\.(?:key|has_key|include|member)\?\(["']([A-Z][A-Z0-9_]*)["']\)`),
+			Description: "Ruby ENV.key?() checks",
+			Language:    "ruby",
+			Classify:    classifyExistence,
+		},
 		{
 			Name:        "PHP getenv",
 			Pattern:     regexp.MustCompile(`getenv\(["']([A-Z][A-Z0-9_]*)["']\)`),
@@ -357,6 +756,7 @@ func getCommonPatterns() []VariablePattern {
 			Pattern:     regexp.MustCompile(`["']([A-Z][A-Z0-9_]{3,})["']`),
 			Description: "Environment variable names in strings (lower confidence)",
 			Language:    "generic",
+			Classify:    classifyUnknown,
 		},
 	}
 }
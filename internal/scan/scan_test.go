@@ -0,0 +1,184 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// writeProject creates a tree where every variable is used from many files,
+// so merge order shows up in the usage lists
+func writeProject(t *testing.T, files int) string {
+	t.Helper()
+
+	root := t.TempDir()
+	for i := 0; i < files; i++ {
+		dir := filepath.Join(root, fmt.Sprintf("pkg%d", i%7))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		var src string
+		switch i % 3 {
+		case 0:
+			src = fmt.Sprintf("const url = process.env.DATABASE_URL;\nconst v = process.env.VAR_%d || 'x';\n", i)
+			dir = filepath.Join(dir, fmt.Sprintf("app%d.js", i))
+		case 1:
+			src = fmt.Sprintf("import os\nurl = os.environ[\"DATABASE_URL\"]\nv = os.getenv(\"VAR_%d\")\n", i)
+			dir = filepath.Join(dir, fmt.Sprintf("app%d.py", i))
+		default:
+			src = fmt.Sprintf("package main\n\nimport \"os\"\n\nvar url = os.Getenv(\"DATABASE_URL\")\nvar v = os.Getenv(\"VAR_%d\")\n", i)
+			dir = filepath.Join(dir, fmt.Sprintf("app%d.go", i))
+		}
+		if err := os.WriteFile(dir, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestScanProjectDeterministic(t *testing.T) {
+	root := writeProject(t, 60)
+
+	want, err := NewProjectScanner().WithConcurrency(1).ScanProject(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(want.Files) != 60 || len(want.Variables["DATABASE_URL"]) < 60 {
+		t.Fatalf("unexpected sequential result: %d files, %d DATABASE_URL usages", len(want.Files), len(want.Variables["DATABASE_URL"]))
+	}
+
+	for _, workers := range []int{2, 8, 0} {
+		for run := 0; run < 5; run++ {
+			got, err := NewProjectScanner().WithConcurrency(workers).ScanProject(root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.Files, want.Files) {
+				t.Fatalf("workers=%d: files in a different order", workers)
+			}
+			if !reflect.DeepEqual(got.Variables, want.Variables) {
+				t.Fatalf("workers=%d: variables differ from the sequential scan", workers)
+			}
+		}
+	}
+}
+
+func TestScanProjectContextCancelled(t *testing.T) {
+	root := writeProject(t, 20)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := NewProjectScanner().ScanProjectContext(ctx, root)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if result == nil {
+		t.Fatal("expected a partial result")
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	root := writeProject(t, 6)
+	result, err := NewProjectScanner().ScanProject(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summary := result.Summarize("DATABASE_URL")
+	if summary.Name != "DATABASE_URL" || summary.Usages != len(result.Variables["DATABASE_URL"]) {
+		t.Errorf("Summarize = %+v, want %d usages", summary, len(result.Variables["DATABASE_URL"]))
+	}
+	if !sort.StringsAreSorted(summary.Languages) {
+		t.Errorf("Languages not sorted: %v", summary.Languages)
+	}
+	languages := make(map[string]bool)
+	for _, language := range summary.Languages {
+		languages[language] = true
+	}
+	for _, language := range []string{"go", "javascript", "python"} {
+		if !languages[language] {
+			t.Errorf("Languages = %v, want %s among them", summary.Languages, language)
+		}
+	}
+	if len(summary.Files) != 6 {
+		t.Errorf("Files = %v, want 6 files", summary.Files)
+	}
+	for i := 1; i < len(summary.Files); i++ {
+		if summary.Files[i-1] >= summary.Files[i] {
+			t.Errorf("Files not sorted and unique: %v", summary.Files)
+		}
+	}
+	if summary.Confidence <= 0 || summary.Confidence > 1 {
+		t.Errorf("Confidence = %v, want an average in (0, 1]", summary.Confidence)
+	}
+}
+
+func TestSummarizeAggregates(t *testing.T) {
+	result := &ScanResult{Variables: map[string][]UsageResult{
+		"PORT": {
+			{File: "b.js", Language: "javascript", Confidence: 0.9, Requirement: RequirementOptional, Default: "3000"},
+			{File: "a.py", Language: "python", Confidence: 0.6, Requirement: RequirementOptional, Default: "3000"},
+			{File: "b.js", Language: "javascript", Confidence: 0.6, Requirement: RequirementOptional, Default: "8080"},
+		},
+	}}
+
+	got := result.Summarize("PORT")
+	want := VariableSummary{
+		Name:        "PORT",
+		Confidence:  0.7,
+		Usages:      3,
+		Files:       []string{"a.py", "b.js"},
+		Languages:   []string{"javascript", "python"},
+		Requirement: RequirementOptional,
+		Defaults:    []string{"3000", "8080"},
+	}
+	if got.Confidence < 0.6999 || got.Confidence > 0.7001 {
+		t.Errorf("Confidence = %v, want 0.7", got.Confidence)
+	}
+	got.Confidence = want.Confidence
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Summarize = %+v, want %+v", got, want)
+	}
+
+	if unknown := result.Summarize("MISSING"); unknown.Usages != 0 || unknown.Confidence != 0 {
+		t.Errorf("Summarize of an unknown variable = %+v, want no usages", unknown)
+	}
+}
+
+func TestGetDiscoveredVariables(t *testing.T) {
+	result := &ScanResult{Variables: map[string][]UsageResult{
+		"SURE":   {{Confidence: 0.9}, {Confidence: 0.8}},
+		"UNSURE": {{Confidence: 0.9}, {Confidence: 0.1}},
+		"ONCE":   {{Confidence: 0.9}},
+		"EVEN":   {{Confidence: 0.5}, {Confidence: 0.5}},
+	}}
+
+	tests := []struct {
+		minConfidence float64
+		minUsages     int
+		expected      []string
+	}{
+		{minConfidence: 0, minUsages: 1, expected: []string{"EVEN", "ONCE", "SURE", "UNSURE"}},
+		{minConfidence: 0.5, minUsages: 1, expected: []string{"EVEN", "ONCE", "SURE", "UNSURE"}},
+		{minConfidence: 0.6, minUsages: 1, expected: []string{"ONCE", "SURE"}},
+		{minConfidence: 0.6, minUsages: 2, expected: []string{"SURE"}},
+		{minConfidence: 0.95, minUsages: 1, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v/%d", tt.minConfidence, tt.minUsages), func(t *testing.T) {
+			got := result.GetDiscoveredVariables(tt.minConfidence, tt.minUsages)
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("GetDiscoveredVariables = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
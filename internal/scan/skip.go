@@ -0,0 +1,124 @@
+package scan
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SkipReason explains why a file matching the include rules wasn't scanned
+type SkipReason string
+
+const (
+	SkipBinary   SkipReason = "binary"
+	SkipTooLarge SkipReason = "too large"
+	SkipMinified SkipReason = "minified"
+)
+
+// SkippedFile is a file left out of a scan
+type SkippedFile struct {
+	File   string     `json:"file"`
+	Reason SkipReason `json:"reason"`
+	Detail string     `json:"detail,omitempty"`
+}
+
+// SkipOptions controls which files are skipped before scanning
+type SkipOptions struct {
+	Binary      bool  // files with a NUL byte near the start, like git's heuristic
+	Minified    bool  // *.min.* files and files whose lines average over minifiedLineLength
+	MaxFileSize int64 // in bytes, 0 for no limit
+}
+
+// DefaultSkipOptions skips binary and minified files and files over 1 MiB
+func DefaultSkipOptions() SkipOptions {
+	return SkipOptions{
+		Binary:      true,
+		Minified:    true,
+		MaxFileSize: 1 << 20,
+	}
+}
+
+const (
+	// binarySniffLength is how much of a file is searched for NUL bytes
+	binarySniffLength = 8000
+	// minifiedLineLength is the average line length above which a file is
+	// considered minified; hand-written code rarely averages over 100
+	minifiedLineLength = 500
+	// minifiedMinSize keeps short one-line files from counting as minified
+	minifiedMinSize = 1024
+	// maxLineLength bounds a single line when there is no file size limit
+	maxLineLength = 64 << 20
+)
+
+// readFile reads a file to scan, or reports why it should be skipped
+func (ps *ProjectScanner) readFile(path string) ([]byte, *SkippedFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if max := ps.skip.MaxFileSize; max > 0 && info.Size() > max {
+		return nil, &SkippedFile{
+			File:   path,
+			Reason: SkipTooLarge,
+			Detail: fmt.Sprintf("%s, limit %s", formatSize(info.Size()), formatSize(max)),
+		}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ps.skip.Binary && isBinary(data) {
+		return nil, &SkippedFile{File: path, Reason: SkipBinary}, nil
+	}
+	if ps.skip.Minified {
+		if minified, detail := isMinified(path, data); minified {
+			return nil, &SkippedFile{File: path, Reason: SkipMinified, Detail: detail}, nil
+		}
+	}
+
+	return data, nil, nil
+}
+
+// lineLimit is the longest line scanFile accepts
+func (ps *ProjectScanner) lineLimit() int {
+	if ps.skip.MaxFileSize > 0 && ps.skip.MaxFileSize < maxLineLength {
+		return int(ps.skip.MaxFileSize) + 1
+	}
+	return maxLineLength
+}
+
+func isBinary(data []byte) bool {
+	if len(data) > binarySniffLength {
+		data = data[:binarySniffLength]
+	}
+	return bytes.IndexByte(data, 0) >= 0
+}
+
+func isMinified(path string, data []byte) (bool, string) {
+	if strings.Contains(strings.ToLower(filepath.Base(path)), ".min.") {
+		return true, "*.min.* file name"
+	}
+	if len(data) < minifiedMinSize {
+		return false, ""
+	}
+	lines := bytes.Count(data, []byte("\n")) + 1
+	if average := len(data) / lines; average > minifiedLineLength {
+		return true, fmt.Sprintf("lines average %d characters", average)
+	}
+	return false, ""
+}
+
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
@@ -0,0 +1,88 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadFileSkips(t *testing.T) {
+	long := "const k = process.env.LONG_KEY;" + strings.Repeat("a", 200000) + "\n"
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		opts    SkipOptions
+		want    SkipReason
+	}{
+		{"plain source", "app.js", "const a = process.env.API_KEY;\n", DefaultSkipOptions(), ""},
+		{"nul byte", "blob.js", "var a\x00b = process.env.API_KEY\n", DefaultSkipOptions(), SkipBinary},
+		{"binary allowed", "blob.js", "var a\x00b\n", SkipOptions{}, ""},
+		{"min file name", "lib.min.js", "x=process.env.API_KEY;", DefaultSkipOptions(), SkipMinified},
+		{"long lines", "bundle.js", long, DefaultSkipOptions(), SkipMinified},
+		{"short one-liner", "one.js", "module.exports = process.env.API_KEY;", DefaultSkipOptions(), ""},
+		{"minified allowed", "bundle.js", long, SkipOptions{}, ""},
+		{"over size limit", "fixture.json", strings.Repeat("x", 2048), SkipOptions{MaxFileSize: 1024}, SkipTooLarge},
+		{"no size limit", "fixture.json", strings.Repeat("x\n", 2048), SkipOptions{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, skipped, err := NewProjectScanner().WithSkipOptions(tt.opts).readFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got SkipReason
+			if skipped != nil {
+				got = skipped.Reason
+			}
+			if got != tt.want {
+				t.Errorf("readFile(%s) skipped as %q, want %q", tt.file, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanProjectSkipped(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"app.js":     "const a = process.env.API_KEY;\n",
+		"lib.min.js": "x=process.env.MIN_KEY;",
+		"long.js":    "const k = process.env.LONG_KEY;" + strings.Repeat("a", 200000) + "\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := NewProjectScanner().ScanProject(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Files) != 1 || len(result.Skipped) != 2 {
+		t.Fatalf("got %d files and %d skipped, want 1 and 2", len(result.Files), len(result.Skipped))
+	}
+	if _, ok := result.Variables["MIN_KEY"]; ok {
+		t.Errorf("minified file was scanned")
+	}
+
+	// Lines longer than bufio's default limit are scanned when allowed
+	result, err = NewProjectScanner().WithSkipOptions(SkipOptions{}).ScanProject(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Errors) > 0 {
+		t.Errorf("unexpected errors: %v", result.Errors)
+	}
+	if len(result.Variables["LONG_KEY"]) != 1 {
+		t.Errorf("LONG_KEY not found in a file with a 200 KB line")
+	}
+}
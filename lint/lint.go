@@ -10,6 +10,7 @@ import (
 // Linter provides better error handling and parsing issues integration
 type Linter struct {
 	rules              []rules.Rule
+	multiFileRules     []rules.MultiFileRule
 	parser             *parse.EnhancedParser
 	includeParseIssues bool
 }
@@ -27,6 +28,13 @@ func (l *Linter) WithRule(rule rules.Rule) *Linter {
 	return l
 }
 
+// WithMultiFileRule adds a rule that compares the linted files with each
+// other. It runs once every file has been parsed.
+func (l *Linter) WithMultiFileRule(rule rules.MultiFileRule) *Linter {
+	l.multiFileRules = append(l.multiFileRules, rule)
+	return l
+}
+
 func (l *Linter) WithParseIssues(include bool) *Linter {
 	l.includeParseIssues = include
 	return l
@@ -34,6 +42,7 @@ func (l *Linter) WithParseIssues(include bool) *Linter {
 
 func (l *Linter) Lint(files []string) ([]issues.Issue, error) {
 	var allIssues []issues.Issue
+	parsed := make([]rules.FileVars, 0, len(files))
 
 	for _, file := range files {
 		// Parse with detailed error reporting
@@ -52,6 +61,12 @@ func (l *Linter) Lint(files []string) ([]issues.Issue, error) {
 			ruleIssues := rule(result.Vars, file)
 			allIssues = append(allIssues, ruleIssues...)
 		}
+
+		parsed = append(parsed, rules.FileVars{File: file, Vars: result.Vars})
+	}
+
+	for _, rule := range l.multiFileRules {
+		allIssues = append(allIssues, rule(parsed)...)
 	}
 
 	return allIssues, nil
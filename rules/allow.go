@@ -0,0 +1,163 @@
+package rules
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/tahcohcat/ecolint/domain/issues"
+)
+
+// AllowEntry marks values the security rule should not report, such as
+// publishable Stripe keys. Every field that is set must match. Values are
+// identified by hash so the allowlist never holds the plaintext.
+type AllowEntry struct {
+	Key     string `yaml:"key,omitempty"`     // variable name, * and ? wildcards allowed
+	SHA256  string `yaml:"sha256,omitempty"`  // hex SHA-256 of the value, see HashValue
+	Pattern string `yaml:"pattern,omitempty"` // regular expression the value must match
+	Reason  string `yaml:"reason,omitempty"`  // why the value is allowed
+
+	re *regexp.Regexp
+}
+
+// HashValue returns the hex SHA-256 of a value, as used by AllowEntry
+func HashValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// Compile checks the entry and prepares its pattern
+func (e *AllowEntry) Compile() error {
+	if e.Key == "" && e.SHA256 == "" && e.Pattern == "" {
+		return fmt.Errorf("allow entry needs a key, sha256 or pattern")
+	}
+	if e.SHA256 != "" {
+		e.SHA256 = strings.ToLower(e.SHA256)
+		if _, err := hex.DecodeString(e.SHA256); err != nil || len(e.SHA256) != sha256.Size*2 {
+			return fmt.Errorf("allow entry %s: sha256 must be 64 hex characters", e)
+		}
+	}
+	if e.Pattern != "" {
+		re, err := regexp.Compile(e.Pattern)
+		if err != nil {
+			return fmt.Errorf("allow entry %s: invalid pattern: %w", e, err)
+		}
+		e.re = re
+	}
+	return nil
+}
+
+// Matches reports whether the entry allows a variable
+func (e AllowEntry) Matches(key, value string) bool {
+	if e.Key != "" {
+		if ok, _ := path.Match(e.Key, key); !ok {
+			return false
+		}
+	}
+	if e.SHA256 != "" && HashValue(value) != e.SHA256 {
+		return false
+	}
+	if e.Pattern != "" {
+		if e.re == nil {
+			if err := e.Compile(); err != nil {
+				return false
+			}
+		}
+		if !e.re.MatchString(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// String describes the entry, e.g. "key STRIPE_PUBLISHABLE_KEY, sha256 3a7bd3e2360a…"
+func (e AllowEntry) String() string {
+	var parts []string
+	if e.Key != "" {
+		parts = append(parts, "key "+e.Key)
+	}
+	if e.SHA256 != "" {
+		short := e.SHA256
+		if len(short) > 12 {
+			short = short[:12] + "…"
+		}
+		parts = append(parts, "sha256 "+short)
+	}
+	if e.Pattern != "" {
+		parts = append(parts, "pattern "+e.Pattern)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// CompileAllowlist checks every entry, see AllowEntry.Compile
+func CompileAllowlist(allow []AllowEntry) ([]AllowEntry, error) {
+	out := append([]AllowEntry{}, allow...)
+	for i := range out {
+		if err := out[i].Compile(); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// AllowUsage records which security.allow entries matched a variable
+// while linting, so that entries nothing matches can be reported once every
+// file is linted. Set it on SecurityOptions and pass it to
+// UnusedAllowEntries.
+type AllowUsage struct {
+	mu      sync.Mutex
+	matched map[int]bool // by index in SecurityOptions.Allow
+}
+
+// NewAllowUsage returns an empty record
+func NewAllowUsage() *AllowUsage {
+	return &AllowUsage{matched: make(map[int]bool)}
+}
+
+func (u *AllowUsage) record(i int) {
+	if u == nil {
+		return
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.matched[i] = true
+}
+
+func (u *AllowUsage) used(i int) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.matched[i]
+}
+
+// UnusedAllowEntries reports security.allow entries that matched no
+// variable in any linted file, as recorded in usage by the security rule:
+// the value was rotated, the variable was removed, or the entry never
+// matched at all. Run it on a lint of the whole project, since entries for
+// files left out of the lint would be reported too. Issues are reported
+// against source, the config file.
+func UnusedAllowEntries(allow []AllowEntry, usage *AllowUsage, source string) MultiFileRule {
+	return func([]FileVars) []issues.Issue {
+		var out []issues.Issue
+		for i, entry := range allow {
+			if usage.used(i) {
+				continue
+			}
+			out = append(out, issues.NewIssue(
+				"unused allowlist entry",
+				entry.String(),
+				source,
+				0,
+				0,
+				[]string{
+					"No variable in the linted files matches this security.allow entry",
+					"Remove the entry if the value was rotated or the variable removed, or allow the new value with ecolint secrets allow",
+				},
+			))
+		}
+		return out
+	}
+}
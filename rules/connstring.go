@@ -0,0 +1,127 @@
+package rules
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// URLCredential is a password written inline in a connection string
+type URLCredential struct {
+	Scheme   string // e.g. "postgres"; "" for key=value DSNs
+	Password string
+	Host     string // without the port
+	fix      string // the value with the password replaced by a reference
+}
+
+var (
+	// user:pass@tcp(host:3306)/db, as used by go-sql-driver/mysql
+	mysqlDSN = regexp.MustCompile(`^([^:@/\s]*):([^@\s]+)@(?:tcp|unix)\(([^)]*)\)`)
+	// host=db password=secret, or Server=db;Password=secret;
+	dsnPassword = regexp.MustCompile(`(?i)(^|[\s;])(password|pwd)\s*=\s*('[^']*'|"[^"]*"|[^;\s]+)`)
+	dsnHost     = regexp.MustCompile(`(?i)(?:^|[\s;])(?:host|server|data source|hostaddr)\s*=\s*([^;\s]+)`)
+)
+
+// FindURLCredential looks for an inline password in a URL such as
+// postgres://admin:hunter2@db/app, a MySQL DSN such as
+// admin:hunter2@tcp(db:3306)/app or a key=value DSN such as
+// "host=db password=hunter2". Passwords that IsPlaceholder reports as
+// placeholders, such as ${DB_PASSWORD}, are ignored.
+func FindURLCredential(value string, placeholders []string) (URLCredential, bool) {
+	var c URLCredential
+	switch {
+	case strings.Contains(value, "://"):
+		c = parseURLCredential(value)
+	case mysqlDSN.MatchString(value):
+		m := mysqlDSN.FindStringSubmatchIndex(value)
+		c = URLCredential{
+			Scheme:   "mysql",
+			Password: value[m[4]:m[5]],
+			Host:     hostOnly(value[m[6]:m[7]]),
+		}
+		c.fix = value[:m[4]] + "${PASSWORD}" + value[m[5]:]
+	case dsnPassword.MatchString(value):
+		m := dsnPassword.FindStringSubmatchIndex(value)
+		c.Password = strings.Trim(value[m[6]:m[7]], `'"`)
+		if h := dsnHost.FindStringSubmatch(value); h != nil {
+			c.Host = hostOnly(h[1])
+		}
+		c.fix = value[:m[6]] + "${PASSWORD}" + value[m[7]:]
+	}
+
+	if c.Password == "" || IsPlaceholder(c.Password, placeholders) {
+		return URLCredential{}, false
+	}
+	return c, true
+}
+
+func parseURLCredential(value string) URLCredential {
+	scheme, rest, _ := strings.Cut(value, "://")
+
+	// The userinfo ends at the last @ before the query, so unescaped /
+	// in passwords is tolerated. Parse it by hand since multi-host URLs
+	// such as mongodb://u:p@a:27017,b:27017 aren't valid for net/url.
+	head := rest
+	if i := strings.IndexAny(head, "?#"); i >= 0 {
+		head = head[:i]
+	}
+	at := strings.LastIndex(head, "@")
+	if at < 0 {
+		return URLCredential{}
+	}
+	user, password, found := strings.Cut(head[:at], ":")
+	if !found {
+		return URLCredential{}
+	}
+	if unescaped, err := url.PathUnescape(password); err == nil {
+		password = unescaped
+	}
+
+	host := head[at+1:]
+	if i := strings.IndexAny(host, ",/"); i >= 0 {
+		host = host[:i]
+	}
+
+	return URLCredential{
+		Scheme:   strings.ToLower(scheme),
+		Password: password,
+		Host:     hostOnly(host),
+		fix:      scheme + "://" + user + ":${PASSWORD}" + rest[at:],
+	}
+}
+
+func hostOnly(hostport string) string {
+	if host, _, err := net.SplitHostPort(hostport); err == nil {
+		return host
+	}
+	return strings.Trim(hostport, "[]")
+}
+
+// isLocalHost reports whether a host only exists on a developer's machine,
+// where connection strings usually hold throwaway defaults
+func (c URLCredential) isLocalHost() bool {
+	switch strings.ToLower(c.Host) {
+	case "localhost", "127.0.0.1", "::1", "0.0.0.0", "host.docker.internal":
+		return true
+	}
+	return false
+}
+
+// Fix returns the value with its password replaced by a reference to
+// passwordVar, e.g. postgres://admin:${DATABASE_PASSWORD}@db/app
+func (c URLCredential) Fix(passwordVar string) string {
+	return strings.Replace(c.fix, "${PASSWORD}", "${"+passwordVar+"}", 1)
+}
+
+// passwordVarFor suggests a variable name for a password split out of key,
+// e.g. DATABASE_URL becomes DATABASE_PASSWORD
+func passwordVarFor(key string) string {
+	base := strings.ToUpper(key)
+	for _, suffix := range []string{"_URL", "_URI", "_DSN", "_CONNECTION_STRING", "_CONN"} {
+		if strings.HasSuffix(base, suffix) {
+			return strings.TrimSuffix(base, suffix) + "_PASSWORD"
+		}
+	}
+	return base + "_PASSWORD"
+}
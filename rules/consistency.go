@@ -0,0 +1,91 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tahcohcat/ecolint/domain/issues"
+)
+
+// DefaultAllowDiffer lists files that hold per-developer overrides rather
+// than a full environment, following the dotenv convention
+var DefaultAllowDiffer = []string{".env.local", ".env.*.local"}
+
+// Consistency compares environment variants in the same directory, such as
+// .env.staging and .env.production, and reports keys that some variants
+// define and others lack. The template, usually .env.example, is checked
+// by the Example rule instead, and files whose names match an allowDiffer
+// pattern (* and ? wildcards) are left out of the comparison.
+func Consistency(template string, allowDiffer []string) MultiFileRule {
+	return func(files []FileVars) []issues.Issue {
+		var out []issues.Issue
+
+		groups := make(map[string][]FileVars)
+		var dirs []string
+		for _, f := range files {
+			name := filepath.Base(f.File)
+			if name == template || MatchesAny(name, allowDiffer) {
+				continue
+			}
+			dir := filepath.Dir(f.File)
+			if _, ok := groups[dir]; !ok {
+				dirs = append(dirs, dir)
+			}
+			groups[dir] = append(groups[dir], f)
+		}
+		sort.Strings(dirs)
+
+		for _, dir := range dirs {
+			out = append(out, compareVariants(groups[dir])...)
+		}
+		return out
+	}
+}
+
+func compareVariants(variants []FileVars) []issues.Issue {
+	if len(variants) < 2 {
+		return nil
+	}
+	sort.Slice(variants, func(i, j int) bool { return variants[i].File < variants[j].File })
+
+	// Keys in order of first definition, with the files defining them
+	var keys []string
+	definedIn := make(map[string][]string)
+	defined := make([]map[string]bool, len(variants))
+	for i, v := range variants {
+		defined[i] = make(map[string]bool)
+		for _, variable := range v.Vars {
+			if defined[i][variable.Key] {
+				continue
+			}
+			defined[i][variable.Key] = true
+			if definedIn[variable.Key] == nil {
+				keys = append(keys, variable.Key)
+			}
+			definedIn[variable.Key] = append(definedIn[variable.Key], filepath.Base(v.File))
+		}
+	}
+
+	var out []issues.Issue
+	for i, v := range variants {
+		for _, key := range keys {
+			if defined[i][key] {
+				continue
+			}
+			out = append(out, issues.NewIssue(
+				"inconsistent variable",
+				key,
+				v.File,
+				0,
+				0,
+				[]string{
+					fmt.Sprintf("Defined in %s but not in this file", strings.Join(definedIn[key], ", ")),
+					"Add it here, or list this file under consistency.allow_differ if it may differ",
+				},
+			))
+		}
+	}
+	return out
+}
@@ -0,0 +1,98 @@
+package rules
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/tahcohcat/ecolint/domain/env"
+)
+
+func keyVars(keys ...string) []env.Var {
+	out := make([]env.Var, len(keys))
+	for i, key := range keys {
+		out[i] = env.Var{Key: key, Value: "x", Line: i + 1}
+	}
+	return out
+}
+
+func TestConsistency(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []FileVars
+		expected []string // "file:key"
+	}{
+		{
+			name: "variants agree",
+			files: []FileVars{
+				{File: ".env.staging", Vars: keyVars("DATABASE_URL", "REDIS_URL")},
+				{File: ".env.production", Vars: keyVars("REDIS_URL", "DATABASE_URL")},
+			},
+		},
+		{
+			name: "missing from one variant",
+			files: []FileVars{
+				{File: ".env.staging", Vars: keyVars("DATABASE_URL", "REDIS_URL")},
+				{File: ".env.production", Vars: keyVars("DATABASE_URL")},
+				{File: ".env", Vars: keyVars("DATABASE_URL", "REDIS_URL", "DEBUG")},
+			},
+			expected: []string{
+				".env.production:DEBUG",
+				".env.production:REDIS_URL",
+				".env.staging:DEBUG",
+			},
+		},
+		{
+			name: "local overrides may differ",
+			files: []FileVars{
+				{File: ".env", Vars: keyVars("DATABASE_URL", "REDIS_URL")},
+				{File: ".env.local", Vars: keyVars("DEBUG")},
+				{File: ".env.development.local", Vars: keyVars("DEBUG")},
+				{File: ".env.test", Vars: keyVars("TEST_DB")},
+			},
+		},
+		{
+			name: "example template is not a variant",
+			files: []FileVars{
+				{File: ".env", Vars: keyVars("DATABASE_URL")},
+				{File: ".env.production", Vars: keyVars("DATABASE_URL")},
+				{File: ".env.example", Vars: keyVars("DATABASE_URL", "SENTRY_DSN")},
+			},
+		},
+		{
+			name: "directories are compared separately",
+			files: []FileVars{
+				{File: "api/.env", Vars: keyVars("PORT")},
+				{File: "worker/.env", Vars: keyVars("QUEUE_URL")},
+			},
+		},
+		{
+			name: "duplicates count once",
+			files: []FileVars{
+				{File: "api/.env", Vars: keyVars("PORT", "PORT")},
+				{File: "api/.env.prod", Vars: keyVars("HOST")},
+			},
+			expected: []string{
+				"api/.env.prod:PORT",
+				"api/.env:HOST",
+			},
+		},
+	}
+
+	rule := Consistency(".env.example", append([]string{".env.test"}, DefaultAllowDiffer...))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, issue := range rule(tt.files) {
+				if issue.Name != "inconsistent variable" {
+					t.Errorf("unexpected issue %q", issue.Name)
+				}
+				got = append(got, issue.File+":"+issue.Key)
+			}
+			sort.Strings(got)
+			if strings.Join(got, " ") != strings.Join(tt.expected, " ") {
+				t.Errorf("got %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tahcohcat/ecolint/domain/env"
+	"github.com/tahcohcat/ecolint/domain/issues"
+)
+
+// DeclaredVar is a variable set by a deployment manifest
+type DeclaredVar struct {
+	Name   string
+	Source string // where it is declared, e.g. "k8s/api.yaml:12 (Deployment/api)"
+}
+
+// Deployment compares env files with the variables that Kubernetes,
+// docker-compose and Helm manifests set. It reports variables the deployment
+// sets that a file lacks, and variables a file defines that no deployment
+// sets. Files the deployment loads wholesale, such as a compose env_file,
+// are skipped since they are the deployment's source of truth.
+func Deployment(declared []DeclaredVar, loadedFiles []string) Rule {
+	sources := make(map[string][]string)
+	for _, d := range declared {
+		sources[d.Name] = append(sources[d.Name], d.Source)
+	}
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+		sort.Strings(sources[name])
+	}
+	sort.Strings(names)
+
+	loaded := make(map[string]bool)
+	for _, f := range loadedFiles {
+		loaded[absPath(f)] = true
+	}
+
+	return func(vars []env.Var, file string) []issues.Issue {
+		if len(declared) == 0 || loaded[absPath(file)] {
+			return nil
+		}
+
+		var out []issues.Issue
+
+		defined := make(map[string]bool)
+		for _, v := range vars {
+			defined[v.Key] = true
+		}
+
+		for _, name := range names {
+			if defined[name] {
+				continue
+			}
+			out = append(out, issues.NewIssue(
+				"missing deployment variable",
+				name,
+				file,
+				0,
+				0,
+				[]string{
+					fmt.Sprintf("Deployment sets %s in %s", name, strings.Join(sources[name], ", ")),
+					"Add it to this file so local runs match the deployment",
+				},
+			))
+		}
+
+		reported := make(map[string]bool)
+		for _, v := range vars {
+			if sources[v.Key] != nil || reported[v.Key] {
+				continue
+			}
+			reported[v.Key] = true
+			out = append(out, issues.NewIssue(
+				"variable not in deployment",
+				v.Key,
+				file,
+				v.Line,
+				v.Line,
+				[]string{
+					"No Kubernetes, docker-compose or Helm manifest sets this variable",
+					"Add it to the deployment, or remove it if it is only needed locally",
+				},
+			))
+		}
+
+		return out
+	}
+}
+
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
@@ -0,0 +1,66 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/tahcohcat/ecolint/domain/env"
+)
+
+func TestDeployment(t *testing.T) {
+	declared := []DeclaredVar{
+		{Name: "DATABASE_URL", Source: "k8s/api.yaml:19 (Deployment/api (web))"},
+		{Name: "PORT", Source: "k8s/api.yaml:24 (Deployment/api (web))"},
+		{Name: "PORT", Source: "docker-compose.yml:8 (service web)"},
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		vars     []env.Var
+		expected map[string]string // key -> issue name
+	}{
+		{
+			name: "in sync",
+			file: ".env",
+			vars: []env.Var{
+				{Key: "DATABASE_URL", Value: "postgres://localhost", Line: 1},
+				{Key: "PORT", Value: "8080", Line: 2},
+			},
+			expected: map[string]string{},
+		},
+		{
+			name: "both directions",
+			file: ".env",
+			vars: []env.Var{
+				{Key: "PORT", Value: "8080", Line: 1},
+				{Key: "LOCAL_ONLY", Value: "1", Line: 2},
+			},
+			expected: map[string]string{
+				"DATABASE_URL": "missing deployment variable",
+				"LOCAL_ONLY":   "variable not in deployment",
+			},
+		},
+		{
+			name:     "file loaded by the deployment",
+			file:     ".env.worker",
+			vars:     []env.Var{{Key: "LOCAL_ONLY", Value: "1", Line: 1}},
+			expected: map[string]string{},
+		},
+	}
+
+	rule := Deployment(declared, []string{"./.env.worker"})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rule(tt.vars, tt.file)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected %d issues, got %d: %v", len(tt.expected), len(got), got)
+			}
+			for _, issue := range got {
+				if tt.expected[issue.Key] != issue.Name {
+					t.Errorf("unexpected issue %q for %s", issue.Name, issue.Key)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Detector recognises one provider's credential format. Detectors from the
+// security.detectors section of the config are added to DefaultDetectors,
+// replacing any with the same ID.
+type Detector struct {
+	ID         string     `yaml:"id"`
+	Provider   string     `yaml:"provider"`    // e.g. "GitHub"
+	Name       string     `yaml:"name"`        // e.g. "personal access token"
+	Pattern    string     `yaml:"pattern"`     // regular expression the value must match
+	Rotate     string     `yaml:"rotate"`      // how to revoke and replace the credential
+	Confidence Confidence `yaml:"confidence"`  // defaults to high
+	PairedWith string     `yaml:"paired_with"` // only match when this detector matched in the same file
+
+	re *regexp.Regexp
+}
+
+// DefaultDetectors are the credential formats ecolint knows about
+var DefaultDetectors = []Detector{
+	{
+		ID: "github-token", Provider: "GitHub", Name: "token",
+		Pattern: `^gh[pousr]_[A-Za-z0-9]{36,}$`,
+		Rotate:  "Revoke it under GitHub Settings → Developer settings (or the OAuth app) and issue a new one",
+	},
+	{
+		ID: "github-fine-grained-token", Provider: "GitHub", Name: "fine-grained personal access token",
+		Pattern: `^github_pat_[A-Za-z0-9_]{22,}$`,
+		Rotate:  "Revoke it under GitHub Settings → Developer settings → Fine-grained tokens and issue a new one",
+	},
+	{
+		ID: "slack-token", Provider: "Slack", Name: "token",
+		Pattern: `^xox[abposr]-[A-Za-z0-9-]{10,}$`,
+		Rotate:  "Revoke it at https://api.slack.com/apps under OAuth & Permissions and reinstall the app",
+	},
+	{
+		ID: "stripe-live-key", Provider: "Stripe", Name: "live secret key",
+		Pattern: `^(sk|rk)_live_[A-Za-z0-9]{24,}$`,
+		Rotate:  "Roll the key in the Stripe Dashboard under Developers → API keys",
+	},
+	{
+		ID: "aws-access-key-id", Provider: "AWS", Name: "access key ID",
+		Pattern: `^(AKIA|ASIA)[0-9A-Z]{16}$`,
+		Rotate:  "Deactivate the key in IAM, create a new one and delete the old one once nothing uses it",
+	},
+	{
+		ID: "aws-secret-access-key", Provider: "AWS", Name: "secret access key",
+		Pattern:    `^[A-Za-z0-9/+]{40}$`,
+		PairedWith: "aws-access-key-id",
+		Rotate:     "Deactivate the access key in IAM, create a new one and delete the old one once nothing uses it",
+	},
+	{
+		ID: "gcp-service-account", Provider: "Google Cloud", Name: "service account key",
+		Pattern: `"type"\s*:\s*"service_account"`,
+		Rotate:  "Delete the key under IAM → Service Accounts → Keys and create a new one, or use workload identity instead",
+	},
+	{
+		ID: "google-api-key", Provider: "Google", Name: "API key",
+		Pattern: `^AIza[0-9A-Za-z_-]{35}$`,
+		Rotate:  "Regenerate the key in the Google Cloud console under APIs & Services → Credentials",
+	},
+	{
+		ID: "private-key", Provider: "PEM", Name: "private key",
+		Pattern: `-----BEGIN ((RSA|EC|DSA|OPENSSH|ENCRYPTED|PGP) )?PRIVATE KEY( BLOCK)?-----`,
+		Rotate:  "Generate a new key pair, replace the public key wherever it is trusted and revoke any certificates for the old one",
+	},
+	{
+		ID: "npm-token", Provider: "npm", Name: "access token",
+		Pattern: `^npm_[A-Za-z0-9]{36}$`,
+		Rotate:  "Revoke it with `npm token revoke` or at npmjs.com under Access Tokens and create a new one",
+	},
+	{
+		ID: "sendgrid-api-key", Provider: "SendGrid", Name: "API key",
+		Pattern: `^SG\.[A-Za-z0-9_-]{22}\.[A-Za-z0-9_-]{43}$`,
+		Rotate:  "Delete the key in SendGrid under Settings → API Keys and create a new one",
+	},
+	{
+		ID: "twilio-api-key", Provider: "Twilio", Name: "API key SID",
+		Pattern: `^SK[0-9a-fA-F]{32}$`,
+		Rotate:  "Delete the API key in the Twilio Console and create a new one",
+	},
+	{
+		// Account SIDs aren't secret, but an auth token next to one is
+		ID: "twilio-account-sid", Provider: "Twilio", Name: "account SID",
+		Pattern:    `^AC[0-9a-fA-F]{32}$`,
+		Confidence: ConfidenceLow,
+	},
+	{
+		ID: "twilio-auth-token", Provider: "Twilio", Name: "auth token",
+		Pattern:    `^[0-9a-fA-F]{32}$`,
+		PairedWith: "twilio-account-sid",
+		Rotate:     "Promote a secondary auth token in the Twilio Console, then delete the old one",
+	},
+	{
+		ID: "jwt", Provider: "JWT", Name: "token",
+		Pattern: `^eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+$`,
+		Rotate:  "Rotate the signing key it was issued with if it doesn't expire soon",
+	},
+}
+
+// defaultDetectors is DefaultDetectors, compiled
+var defaultDetectors = func() []Detector {
+	detectors, err := MergeDetectors(DefaultDetectors, nil)
+	if err != nil {
+		panic(err)
+	}
+	return detectors
+}()
+
+// Compile checks the detector and prepares its pattern
+func (d *Detector) Compile() error {
+	if d.ID == "" {
+		return fmt.Errorf("detector has no id")
+	}
+	if d.Pattern == "" {
+		return fmt.Errorf("detector %s has no pattern", d.ID)
+	}
+	re, err := regexp.Compile(d.Pattern)
+	if err != nil {
+		return fmt.Errorf("detector %s: invalid pattern: %w", d.ID, err)
+	}
+	d.re = re
+
+	if d.Confidence == "" {
+		d.Confidence = ConfidenceHigh
+	}
+	confidence, err := ParseConfidence(string(d.Confidence))
+	if err != nil {
+		return fmt.Errorf("detector %s: %w", d.ID, err)
+	}
+	d.Confidence = confidence
+	return nil
+}
+
+// Description names the credential, e.g. "GitHub token"
+func (d Detector) Description() string {
+	if d.Provider == "" {
+		return d.Name
+	}
+	return d.Provider + " " + d.Name
+}
+
+// MergeDetectors adds custom detectors to base, replacing those with the
+// same ID, and compiles them all
+func MergeDetectors(base, custom []Detector) ([]Detector, error) {
+	out := append([]Detector{}, base...)
+	index := make(map[string]int)
+	for i, d := range out {
+		index[d.ID] = i
+	}
+	for _, d := range custom {
+		if i, ok := index[d.ID]; ok {
+			out[i] = d
+		} else {
+			index[d.ID] = len(out)
+			out = append(out, d)
+		}
+	}
+
+	for i := range out {
+		if err := out[i].Compile(); err != nil {
+			return nil, err
+		}
+	}
+	for _, d := range out {
+		if _, ok := index[d.PairedWith]; d.PairedWith != "" && !ok {
+			return nil, fmt.Errorf("detector %s: paired_with refers to unknown detector %s", d.ID, d.PairedWith)
+		}
+	}
+	return out, nil
+}
+
+func (d Detector) match(value string) bool {
+	if d.re == nil {
+		if err := d.Compile(); err != nil {
+			return false
+		}
+	}
+	return d.re.MatchString(value)
+}
@@ -0,0 +1,117 @@
+package rules
+
+import (
+	"math"
+	"regexp"
+)
+
+// Charset is the smallest character set a value's characters come from
+type Charset string
+
+const (
+	CharsetHex          Charset = "hex"
+	CharsetAlphanumeric Charset = "alphanumeric"
+	CharsetBase64       Charset = "base64" // standard and URL-safe alphabets, with padding
+	CharsetOther        Charset = ""       // punctuation, spaces etc.; not scored
+)
+
+// minClassChanges is the share of adjacent characters that must switch
+// between lowercase, uppercase, digits and symbols. Random strings switch
+// about every other character, identifiers like MyApplicationName2024
+// rarely do.
+const minClassChanges = 0.4
+
+// uuidPattern matches UUIDs, which are random but rarely secret
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Entropy returns the Shannon entropy of s in bits per character
+func Entropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	n := 0
+	for _, r := range s {
+		counts[r]++
+		n++
+	}
+
+	var h float64
+	for _, c := range counts {
+		p := float64(c) / float64(n)
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+// CharsetOf classifies a value by the characters it uses
+func CharsetOf(s string) Charset {
+	hex, alnum := true, true
+	for i, r := range s {
+		switch {
+		case r >= '0' && r <= '9', r >= 'a' && r <= 'f', r >= 'A' && r <= 'F':
+		case r >= 'g' && r <= 'z', r >= 'G' && r <= 'Z':
+			hex = false
+		case r == '+' || r == '/' || r == '-' || r == '_':
+			hex, alnum = false, false
+		case r == '=' && i >= len(s)-2:
+			hex, alnum = false, false
+		default:
+			return CharsetOther
+		}
+	}
+	switch {
+	case s == "":
+		return CharsetOther
+	case hex:
+		return CharsetHex
+	case alnum:
+		return CharsetAlphanumeric
+	}
+	return CharsetBase64
+}
+
+// threshold returns the entropy a value of this length and charset needs
+// to look random. A string of n characters has at most log2(n) bits per
+// character, so for values shorter than the alphabet the threshold is
+// scaled down in proportion.
+func (o SecurityOptions) threshold(charset Charset, length int) float64 {
+	var t, alphabet float64
+	switch charset {
+	case CharsetHex:
+		t, alphabet = o.HexEntropy, 16
+	case CharsetAlphanumeric:
+		t, alphabet = o.AlphanumericEntropy, 62
+	case CharsetBase64:
+		t, alphabet = o.Base64Entropy, 64
+	default:
+		return math.Inf(1)
+	}
+	return t * math.Min(1, math.Log2(float64(length))/math.Log2(alphabet))
+}
+
+// classChanges returns the share of adjacent characters in different classes
+func classChanges(s string) float64 {
+	if len(s) < 2 {
+		return 0
+	}
+	class := func(b byte) int {
+		switch {
+		case b >= 'a' && b <= 'z':
+			return 0
+		case b >= 'A' && b <= 'Z':
+			return 1
+		case b >= '0' && b <= '9':
+			return 2
+		}
+		return 3
+	}
+
+	changes := 0
+	for i := 1; i < len(s); i++ {
+		if class(s[i]) != class(s[i-1]) {
+			changes++
+		}
+	}
+	return float64(changes) / float64(len(s)-1)
+}
@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/tahcohcat/ecolint/domain/env"
+	"github.com/tahcohcat/ecolint/domain/issues"
+	"github.com/tahcohcat/ecolint/parse"
+)
+
+// Example compares each env file with the template in the same directory,
+// usually .env.example, and reports keys the template lacks and keys the
+// file lacks. Files without a template next to them, the template itself
+// and files matching an allowDiffer pattern are not checked. A template
+// that exists but can't be read is reported rather than skipped.
+func Example(template string, allowDiffer []string) Rule {
+	parser := parse.NewEnhanced()
+
+	return func(vars []env.Var, file string) []issues.Issue {
+		name := filepath.Base(file)
+		if name == template || MatchesAny(name, allowDiffer) {
+			return nil
+		}
+
+		templatePath := filepath.Join(filepath.Dir(file), template)
+		templateVars, err := parser.Parse(templatePath)
+		if errors.Is(err, fs.ErrNotExist) {
+			// No template in this directory
+			return nil
+		}
+		if err != nil {
+			return []issues.Issue{issues.NewIssue(
+				"unreadable example",
+				template,
+				file,
+				0,
+				0,
+				[]string{
+					fmt.Sprintf("%s could not be read: %v", templatePath, err),
+					"Fix the template, or remove it if it is not a template",
+				},
+			)}
+		}
+		return compareExample(vars, templateVars, file, template)
+	}
+}
+
+func compareExample(vars, templateVars []env.Var, file, template string) []issues.Issue {
+	var out []issues.Issue
+
+	inTemplate := make(map[string]bool)
+	for _, v := range templateVars {
+		inTemplate[v.Key] = true
+	}
+	inFile := make(map[string]bool)
+	for _, v := range vars {
+		if inFile[v.Key] {
+			continue
+		}
+		inFile[v.Key] = true
+
+		if !inTemplate[v.Key] {
+			out = append(out, issues.NewIssue(
+				"variable not in example",
+				v.Key,
+				file,
+				v.Line,
+				v.Line,
+				[]string{
+					fmt.Sprintf("%s does not document this variable", template),
+					"Run: ecolint sync-example",
+				},
+			))
+		}
+	}
+
+	reported := make(map[string]bool)
+	for _, v := range templateVars {
+		if inFile[v.Key] || reported[v.Key] {
+			continue
+		}
+		reported[v.Key] = true
+		out = append(out, issues.NewIssue(
+			"example variable not set",
+			v.Key,
+			file,
+			0,
+			0,
+			[]string{
+				fmt.Sprintf("%s lists this variable on line %d but this file does not set it", template, v.Line),
+				"Add it here, or run ecolint sync-example if it is no longer used",
+			},
+		))
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExample(t *testing.T) {
+	dir := t.TempDir()
+	template := "# Database\nDATABASE_URL=\nPORT=\n"
+	if err := os.WriteFile(filepath.Join(dir, ".env.example"), []byte(template), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// A template that exists but isn't a readable file
+	if err := os.MkdirAll(filepath.Join(dir, "broken", ".env.example"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		keys     []string
+		expected []string // "issue:key"
+	}{
+		{
+			name: "matches template",
+			file: filepath.Join(dir, ".env"),
+			keys: []string{"PORT", "DATABASE_URL"},
+		},
+		{
+			name: "missing both ways",
+			file: filepath.Join(dir, ".env"),
+			keys: []string{"DATABASE_URL", "DEBUG"},
+			expected: []string{
+				"variable not in example:DEBUG",
+				"example variable not set:PORT",
+			},
+		},
+		{
+			name: "local overrides may differ",
+			file: filepath.Join(dir, ".env.local"),
+			keys: []string{"DEBUG"},
+		},
+		{
+			name: "template is not compared with itself",
+			file: filepath.Join(dir, ".env.example"),
+			keys: []string{"DATABASE_URL"},
+		},
+		{
+			name:     "unreadable template",
+			file:     filepath.Join(dir, "broken", ".env"),
+			keys:     []string{"QUEUE_URL"},
+			expected: []string{"unreadable example:.env.example"},
+		},
+		{
+			name: "no template",
+			file: filepath.Join(dir, "worker", ".env"),
+			keys: []string{"QUEUE_URL"},
+		},
+	}
+
+	rule := Example(".env.example", DefaultAllowDiffer)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, issue := range rule(keyVars(tt.keys...), tt.file) {
+				got = append(got, issue.Name+":"+issue.Key)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Example() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package rules
+
+// Severity describes how serious an issue is
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNotice  Severity = "notice"
+)
+
+// Metadata describes the rule behind an issue
+type Metadata struct {
+	ID          string   // rule name as used in .ecolint.yaml
+	Issue       string   // issue name the rule reports
+	Severity    Severity // default severity of the issue
+	Description string
+}
+
+// metadata lists every issue ecolint can report, including parse issues
+var metadata = []Metadata{
+	{ID: "syntax", Issue: "malformed line", Severity: SeverityError, Description: "Line is not in KEY=VALUE format"},
+	{ID: "syntax", Issue: "empty key", Severity: SeverityError, Description: "Variable name is empty"},
+	{ID: "syntax", Issue: "invalid key format", Severity: SeverityError, Description: "Variable name contains spaces or tabs"},
+	{ID: "empty_values", Issue: "empty value", Severity: SeverityWarning, Description: "Variable has no value"},
+	{ID: "empty_values", Issue: "empty variable", Severity: SeverityWarning, Description: "Variable has no value"},
+	{ID: "duplicate", Issue: "duplicate variable", Severity: SeverityError, Description: "Variable is defined more than once"},
+	{ID: "missing", Issue: "missing required variable", Severity: SeverityError, Description: "Required variable is not defined"},
+	{ID: "security", Issue: "potential secret in plaintext", Severity: SeverityError, Description: "Value looks like a secret committed in plaintext"},
+	{ID: "security", Issue: "secret in connection string", Severity: SeverityError, Description: "URL or DSN has a password written inline"},
+	{ID: "security", Issue: "unused allowlist entry", Severity: SeverityWarning, Description: "A security.allow entry matches no variable in the linted files"},
+	{ID: "deployment", Issue: "missing deployment variable", Severity: SeverityWarning, Description: "Variable is set by a deployment manifest but not defined in this file"},
+	{ID: "deployment", Issue: "variable not in deployment", Severity: SeverityNotice, Description: "Variable is defined in this file but no deployment manifest sets it"},
+	{ID: "schema", Issue: "invalid value", Severity: SeverityError, Description: "Value does not match the type or constraints in the schema"},
+	{ID: "consistency", Issue: "inconsistent variable", Severity: SeverityWarning, Description: "Variable is defined in other variants of this file, such as .env.staging, but not here"},
+	{ID: "example", Issue: "variable not in example", Severity: SeverityWarning, Description: "Variable is set in this file but the template, usually .env.example, does not list it"},
+	{ID: "example", Issue: "unreadable example", Severity: SeverityError, Description: "The template next to this file, usually .env.example, exists but could not be read"},
+	{ID: "example", Issue: "example variable not set", Severity: SeverityWarning, Description: "Variable is listed in the template, usually .env.example, but this file does not set it"},
+	{ID: "unused", Issue: "unused variable", Severity: SeverityWarning, Description: "Variable is defined but no source file reads it"},
+	{ID: "unused", Issue: "possibly unused variable", Severity: SeverityNotice, Description: "Variable is only matched with low confidence or only set by a deployment manifest"},
+	{ID: "convention", Issue: "naming convention violation", Severity: SeverityWarning, Description: "Variable name does not follow UPPER_SNAKE_CASE conventions"},
+}
+
+// Describe returns the metadata for an issue name. Unknown issues are
+// reported as warnings so they're never silently dropped.
+func Describe(issueName string) Metadata {
+	for _, m := range metadata {
+		if m.Issue == issueName {
+			return m
+		}
+	}
+
+	return Metadata{
+		ID:       "unknown",
+		Issue:    issueName,
+		Severity: SeverityWarning,
+	}
+}
+
+// All returns the metadata of every known issue
+func All() []Metadata {
+	out := make([]Metadata, len(metadata))
+	copy(out, metadata)
+	return out
+}
@@ -0,0 +1,55 @@
+package rules
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// DefaultPlaceholders are values that stand in for a real one. They match
+// whole values, ignoring case, and may use * and ? wildcards.
+var DefaultPlaceholders = []string{
+	"changeme", "change_me", "change-me", "changeit", "placeholder",
+	"example", "sample", "dummy", "demo", "test", "fake", "mock",
+	"todo", "tbd", "fixme", "redacted", "none", "null", "nil", "empty", "default",
+	"secret", "password", "passwd", "pass", "pwd", "pw",
+	"localhost", "127.0.0.1",
+	"true", "false", "development", "production", "staging",
+}
+
+// placeholderShapes match whole values that are placeholders by their form
+var placeholderShapes = []*regexp.Regexp{
+	// <api-key>, <your token>
+	regexp.MustCompile(`^<[^<>]+>$`),
+	// your_api_key_here, your-secret, YOUR_TOKEN, yourkeyhere, insert_key_here
+	regexp.MustCompile(`(?i)^(your|insert|enter)[_-][a-z0-9_-]+$`),
+	regexp.MustCompile(`(?i)^your[a-z0-9]*here$`),
+	regexp.MustCompile(`(?i)^[a-z0-9_-]*[_-]here$`),
+	// xxx, ****, xxxx-xxxx-xxxx, sk_live_xxxxxxxx
+	regexp.MustCompile(`(?i)^[x*]{3,}([-_.][x*]{3,})*$`),
+	regexp.MustCompile(`(?i)^[a-z0-9]+_([a-z0-9]+_)*[x*]{6,}$`),
+	// ${VAR}, $VAR, ${A}:${B}, {{ .Value }}, %s
+	regexp.MustCompile(`^(\$\{[^}]+\}|\$[A-Za-z_][A-Za-z0-9_]*|[:/@.-])+$`),
+	regexp.MustCompile(`^\{\{.*\}\}$`),
+	regexp.MustCompile(`^%[sv]$`),
+}
+
+// IsPlaceholder reports whether a value stands in for a real one: it is one
+// of placeholders (whole value, ignoring case, * and ? wildcards), has a
+// placeholder shape such as <api-key>, your_key_here or xxxx, or only
+// references other variables, e.g. ${API_KEY}
+func IsPlaceholder(value string, placeholders []string) bool {
+	for _, shape := range placeholderShapes {
+		if shape.MatchString(value) {
+			return true
+		}
+	}
+
+	lower := strings.ToLower(value)
+	for _, p := range placeholders {
+		if ok, _ := path.Match(strings.ToLower(p), lower); ok {
+			return true
+		}
+	}
+	return false
+}
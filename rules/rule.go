@@ -1,8 +1,30 @@
 package rules
 
 import (
+	"path"
+
 	"github.com/tahcohcat/ecolint/domain/env"
 	"github.com/tahcohcat/ecolint/domain/issues"
 )
 
 type Rule func(vars []env.Var, file string) []issues.Issue
+
+// FileVars is a parsed file, for rules that compare files with each other
+type FileVars struct {
+	File string
+	Vars []env.Var
+}
+
+// MultiFileRule sees every linted file at once, after each has been parsed
+type MultiFileRule func(files []FileVars) []issues.Issue
+
+// MatchesAny reports whether name matches one of the patterns, which may
+// use * and ? wildcards. Rules use it for allow lists of keys and files.
+func MatchesAny(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
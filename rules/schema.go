@@ -0,0 +1,45 @@
+package rules
+
+import (
+	"github.com/tahcohcat/ecolint/domain/env"
+	"github.com/tahcohcat/ecolint/domain/issues"
+	"github.com/tahcohcat/ecolint/domain/schema"
+)
+
+// Schema checks values against the types and constraints in the schema.
+// Empty values are left to the empty_values rule, and variables without a
+// schema entry are not checked.
+func Schema(s schema.Schema) Rule {
+	return func(vars []env.Var, file string) []issues.Issue {
+		var out []issues.Issue
+
+		for _, v := range vars {
+			if v.Value == "" {
+				continue
+			}
+			field, ok := s.Field(v.Key)
+			if !ok {
+				continue
+			}
+			if err := field.Validate(v.Value); err != nil {
+				recommendations := []string{err.Message}
+				if err.Hint != "" {
+					recommendations = append(recommendations, err.Hint)
+				}
+				if field.Description != "" {
+					recommendations = append(recommendations, v.Key+": "+field.Description)
+				}
+				out = append(out, issues.NewIssue(
+					"invalid value",
+					v.Key,
+					file,
+					v.Line,
+					v.Line,
+					recommendations,
+				).WithValue(v.Value))
+			}
+		}
+
+		return out
+	}
+}
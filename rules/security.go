@@ -1,6 +1,7 @@
 package rules
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -8,101 +9,258 @@ import (
 	"github.com/tahcohcat/ecolint/domain/issues"
 )
 
-// Security checks for potential secrets and sensitive data in plaintext
-func Security(vars []env.Var, file string) []issues.Issue {
-	var out []issues.Issue
-
-	// Patterns that might indicate secrets
-	secretKeyPatterns := []*regexp.Regexp{
-		regexp.MustCompile(`(?i)(password|pwd|pass)$`),
-		regexp.MustCompile(`(?i)(secret|key|token)$`),
-		regexp.MustCompile(`(?i)(private|priv)_key$`),
-		regexp.MustCompile(`(?i)api_(key|secret|token)$`),
-		regexp.MustCompile(`(?i)(auth|oauth)_(key|secret|token)$`),
-		regexp.MustCompile(`(?i)(access|refresh)_token$`),
-		regexp.MustCompile(`(?i)jwt_(secret|key)$`),
-		regexp.MustCompile(`(?i)(db|database)_(password|pass|pwd)$`),
-		regexp.MustCompile(`(?i)(smtp|email)_(password|pass|pwd)$`),
-		regexp.MustCompile(`(?i)(aws|gcp|azure)_(secret|key)$`),
-	}
-
-	// Patterns that might indicate actual secret values (not just keys)
-	secretValuePatterns := []*regexp.Regexp{
-		// JWT tokens (base64 with dots)
-		regexp.MustCompile(`^[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+$`),
-		// API keys (long alphanumeric strings)
-		regexp.MustCompile(`^[A-Za-z0-9]{32,}$`),
-		// Base64 encoded data (longer than 20 chars)
-		regexp.MustCompile(`^[A-Za-z0-9+/]{20,}={0,2}$`),
-		// Hex encoded keys (even length, 16+ chars)
-		regexp.MustCompile(`^[a-fA-F0-9]{16,}$`),
-		// AWS-style keys
-		regexp.MustCompile(`^AKIA[0-9A-Z]{16}$`),
-		// Google API keys
-		regexp.MustCompile(`^AIza[0-9A-Za-z_-]{35}$`),
-	}
-
-	for _, v := range vars {
-		// Skip empty values
-		if v.Value == "" {
+// Confidence is how sure the security rule is that a value is a secret
+type Confidence string
+
+const (
+	ConfidenceLow    Confidence = "low"
+	ConfidenceMedium Confidence = "medium"
+	ConfidenceHigh   Confidence = "high"
+)
+
+func (c Confidence) rank() int {
+	switch c {
+	case ConfidenceHigh:
+		return 3
+	case ConfidenceMedium:
+		return 2
+	case ConfidenceLow:
+		return 1
+	}
+	return 0
+}
+
+// ParseConfidence validates a confidence level from the config
+func ParseConfidence(s string) (Confidence, error) {
+	switch c := Confidence(strings.ToLower(strings.TrimSpace(s))); c {
+	case ConfidenceLow, ConfidenceMedium, ConfidenceHigh:
+		return c, nil
+	default:
+		return "", fmt.Errorf("invalid confidence %q (expected low, medium or high)", s)
+	}
+}
+
+// SecurityOptions tunes how the security rule scores values. Entropy
+// thresholds are in bits per character.
+type SecurityOptions struct {
+	MinLength           int          // shorter values are only reported on key-name hints
+	HexEntropy          float64      // for values of 0-9 and a-f only, at most 4
+	AlphanumericEntropy float64      // for values of letters and digits, at most ~5.95
+	Base64Entropy       float64      // for values that also use + / - _ or = padding, at most 6
+	MinConfidence       Confidence   // findings below this are not reported
+	Detectors           []Detector   // provider credential formats, checked before entropy
+	Placeholders        []string     // values never reported, see IsPlaceholder
+	Allow               []AllowEntry // variables never reported, from security.allow
+	AllowUsage          *AllowUsage  // records matched Allow entries, may be nil
+}
+
+// DefaultSecurityOptions returns the thresholds `ecolint lint` uses
+func DefaultSecurityOptions() SecurityOptions {
+	return SecurityOptions{
+		MinLength:           16,
+		HexEntropy:          3.0,
+		AlphanumericEntropy: 3.5,
+		Base64Entropy:       4.0,
+		MinConfidence:       ConfidenceMedium,
+		Detectors:           defaultDetectors,
+		Placeholders:        DefaultPlaceholders,
+	}
+}
+
+// secretKeyPatterns match variable names that suggest the value is sensitive
+var secretKeyPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(password|pwd|pass)$`),
+	regexp.MustCompile(`(?i)(secret|key|token)$`),
+	regexp.MustCompile(`(?i)(private|priv)_key$`),
+	regexp.MustCompile(`(?i)api_(key|secret|token)$`),
+	regexp.MustCompile(`(?i)(auth|oauth)_(key|secret|token)$`),
+	regexp.MustCompile(`(?i)(access|refresh)_token$`),
+	regexp.MustCompile(`(?i)jwt_(secret|key)$`),
+	regexp.MustCompile(`(?i)(db|database)_(password|pass|pwd)$`),
+	regexp.MustCompile(`(?i)(smtp|email)_(password|pass|pwd)$`),
+	regexp.MustCompile(`(?i)(aws|gcp|azure)_(secret|key)$`),
+}
+
+// IsSensitiveKey reports whether a variable name suggests it holds a secret
+func IsSensitiveKey(key string) bool {
+	for _, pattern := range secretKeyPatterns {
+		if pattern.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// LooksLikeSecret reports whether a value has the shape of a secret or API
+// key, or is random enough to be one. It errs towards yes, so it is also
+// true for values such as commit SHAs that the security rule only reports
+// at low confidence.
+func LooksLikeSecret(value string) bool {
+	return DefaultSecurityOptions().LooksLikeSecret(value)
+}
+
+// LooksLikeSecret is like the package-level LooksLikeSecret, using these
+// options' detectors and entropy thresholds
+func (o SecurityOptions) LooksLikeSecret(value string) bool {
+	if d := o.detect(value, nil); d != nil {
+		return true
+	}
+	if _, ok := FindURLCredential(value, o.Placeholders); ok {
+		return true
+	}
+	random, _ := o.isRandom(value)
+	return random
+}
+
+// Finding is the security rule's verdict on one value
+type Finding struct {
+	Confidence Confidence
+	Reasons    []string  // why the value looks like a secret
+	Detector   *Detector // the credential format the value matched, if any
+}
+
+// Score rates how likely a value is to be a secret, combining provider
+// credential formats, its entropy for its character set and hints from the
+// variable name. A zero Confidence means the value doesn't look like a
+// secret at all. Detectors that must be paired never match; SecurityRule
+// checks those against the rest of the file.
+func (o SecurityOptions) Score(key, value string) Finding {
+	return o.score(key, value, nil)
+}
+
+func (o SecurityOptions) score(key, value string, found map[string]bool) Finding {
+	var f Finding
+	keyHint := IsSensitiveKey(key)
+	if keyHint {
+		f.Reasons = append(f.Reasons, "Variable name suggests it holds a secret")
+	}
+
+	if d := o.detect(value, found); d != nil {
+		f.Confidence = d.Confidence
+		f.Detector = d
+		f.Reasons = append(f.Reasons, fmt.Sprintf("Value matches the %s format", d.Description()))
+		return f
+	}
+
+	random, reason := o.isRandom(value)
+	if random {
+		f.Reasons = append(f.Reasons, reason)
+	}
+
+	switch {
+	case random && keyHint:
+		f.Confidence = ConfidenceHigh
+	case keyHint:
+		// Human-chosen passwords have low entropy but are still secrets
+		f.Confidence = ConfidenceMedium
+	case random && o.likelyIdentifier(value):
+		// Commit SHAs, checksums and UUIDs are random but rarely secret
+		f.Confidence = ConfidenceLow
+	case random:
+		f.Confidence = ConfidenceMedium
+	}
+	return f
+}
+
+// detect returns the first detector matching the value. Paired detectors
+// only match if their pair is in found.
+func (o SecurityOptions) detect(value string, found map[string]bool) *Detector {
+	for i := range o.Detectors {
+		d := &o.Detectors[i]
+		if d.PairedWith != "" && !found[d.PairedWith] {
 			continue
 		}
+		if d.match(value) {
+			return d
+		}
+	}
+	return nil
+}
 
-		// Check if the variable name suggests it might contain a secret
-		isSecretKey := false
-		for _, pattern := range secretKeyPatterns {
-			if pattern.MatchString(v.Key) {
-				isSecretKey = true
-				break
+// isRandom reports whether a value has high entropy for its character set,
+// with a description of why
+func (o SecurityOptions) isRandom(value string) (bool, string) {
+	if len(value) < o.MinLength {
+		return false, ""
+	}
+	if uuidPattern.MatchString(value) {
+		return true, "Value is a UUID"
+	}
+
+	charset := CharsetOf(value)
+	entropy := Entropy(value)
+	if entropy < o.threshold(charset, len(value)) {
+		return false, ""
+	}
+	if charset != CharsetHex && classChanges(value) < minClassChanges {
+		return false, ""
+	}
+	return true, fmt.Sprintf("Value has high entropy for %s characters (%.1f bits per character)", charset, entropy)
+}
+
+// likelyIdentifier reports whether a random value is more likely an
+// identifier, such as a commit SHA or UUID, than a secret
+func (o SecurityOptions) likelyIdentifier(value string) bool {
+	return CharsetOf(value) == CharsetHex || uuidPattern.MatchString(value)
+}
+
+// Security checks for potential secrets using the default thresholds
+var Security = SecurityRule(DefaultSecurityOptions())
+
+// SecurityRule checks for potential secrets and sensitive data in plaintext.
+// Each finding carries a confidence level, and findings below
+// opts.MinConfidence are dropped.
+func SecurityRule(opts SecurityOptions) Rule {
+	return func(vars []env.Var, file string) []issues.Issue {
+		var out []issues.Issue
+
+		// Credentials such as AWS secret keys are only recognisable next
+		// to the ID they belong to
+		found := make(map[string]bool)
+		for _, v := range vars {
+			if d := opts.detect(v.Value, nil); d != nil {
+				found[d.ID] = true
 			}
 		}
 
-		// Check if the value looks like a secret
-		looksLikeSecret := false
-		for _, pattern := range secretValuePatterns {
-			if pattern.MatchString(v.Value) {
-				looksLikeSecret = true
-				break
+		for _, v := range vars {
+			if v.Value == "" {
+				continue
 			}
-		}
 
-		// Check for common placeholder values that are safe
-		safePlaceholders := []string{
-			"changeme", "placeholder", "your_key_here", "your_secret_here",
-			"example", "sample", "dummy", "test", "localhost", "127.0.0.1",
-			"true", "false", "development", "production", "staging",
-		}
+			// Values that stand in for a real one, such as <api-key>
+			if IsPlaceholder(v.Value, opts.Placeholders) || opts.allowed(v.Key, v.Value) {
+				continue
+			}
 
-		isSafePlaceholder := false
-		lowerValue := strings.ToLower(v.Value)
-		for _, placeholder := range safePlaceholders {
-			if lowerValue == placeholder || strings.Contains(lowerValue, placeholder) {
-				isSafePlaceholder = true
-				break
+			// Passwords inside connection strings are judged on their own,
+			// since the rest of the URL is neither random nor secret
+			if cred, ok := FindURLCredential(v.Value, opts.Placeholders); ok {
+				confidence := ConfidenceHigh
+				if cred.isLocalHost() {
+					confidence = ConfidenceLow
+				}
+				if confidence.rank() >= opts.MinConfidence.rank() {
+					out = append(out, connectionStringIssue(v, file, cred, confidence))
+				}
+				continue
 			}
-		}
 
-		// Skip if it's a safe placeholder
-		if isSafePlaceholder {
-			continue
-		}
+			finding := opts.score(v.Key, v.Value, found)
+			if finding.Confidence == "" || finding.Confidence.rank() < opts.MinConfidence.rank() {
+				continue
+			}
 
-		// Report issue if key suggests secret OR value looks like secret
-		if isSecretKey || looksLikeSecret {
-			recommendations := []string{
+			recommendations := finding.Reasons
+			if d := finding.Detector; d != nil && d.Rotate != "" {
+				recommendations = append(recommendations, "To rotate it: "+d.Rotate)
+			}
+			recommendations = append(recommendations,
 				"Consider using a secret management system (e.g., HashiCorp Vault, AWS Secrets Manager)",
 				"Use placeholder values in committed files (e.g., 'your_api_key_here')",
 				"Add this file to .gitignore if it contains real secrets",
 				"Use environment-specific files (.env.local) for sensitive data",
-			}
-
-			if isSecretKey && !looksLikeSecret {
-				recommendations = append([]string{"Variable name suggests it may contain sensitive data"}, recommendations...)
-			}
-
-			if looksLikeSecret {
-				recommendations = append([]string{"Value appears to be a secret or API key"}, recommendations...)
-			}
+			)
 
 			out = append(out, issues.NewIssue(
 				"potential secret in plaintext",
@@ -111,9 +269,43 @@ func Security(vars []env.Var, file string) []issues.Issue {
 				v.Line,
 				0,
 				recommendations,
-			))
+			).WithValue(v.Value).WithConfidence(string(finding.Confidence)))
 		}
+
+		return out
+	}
+}
+
+// allowed reports whether an allow entry matches the variable. Every
+// matching entry is recorded, so that none of them is reported as unused.
+func (o SecurityOptions) allowed(key, value string) bool {
+	allowed := false
+	for i, entry := range o.Allow {
+		if entry.Matches(key, value) {
+			o.AllowUsage.record(i)
+			allowed = true
+		}
+	}
+	return allowed
+}
+
+func connectionStringIssue(v env.Var, file string, cred URLCredential, confidence Confidence) issues.Issue {
+	kind := "Connection string"
+	if cred.Scheme != "" {
+		kind = fmt.Sprintf("The %s connection string", cred.Scheme)
 	}
+	passwordVar := passwordVarFor(v.Key)
 
-	return out
+	return issues.NewIssue(
+		"secret in connection string",
+		v.Key,
+		file,
+		v.Line,
+		0,
+		[]string{
+			kind + " has its password inline",
+			fmt.Sprintf("Move the password into its own variable and reference it: %s=%s", v.Key, cred.Fix(passwordVar)),
+			fmt.Sprintf("Keep %s out of committed files, e.g. in .env.local or a secret manager", passwordVar),
+		},
+	).WithValue(v.Value).WithConfidence(string(confidence))
 }
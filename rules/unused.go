@@ -0,0 +1,83 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/tahcohcat/ecolint/domain/env"
+	"github.com/tahcohcat/ecolint/domain/issues"
+)
+
+// Reference records how a project scan saw a variable being read
+type Reference struct {
+	Confidence float64 // highest confidence of any read in code
+	Declared   bool    // set by a deployment manifest
+}
+
+// DefaultUnusedAllow lists variables read by runtimes and third-party tools
+// rather than project code, so a scan never finds them. Entries may use
+// * and ? wildcards.
+var DefaultUnusedAllow = []string{
+	"NODE_ENV", "NODE_OPTIONS", "TZ", "LANG", "LC_ALL", "PATH", "HOME",
+	"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY",
+	"PGHOST", "PGPORT", "PGUSER", "PGPASSWORD", "PGDATABASE", "PGSSLMODE",
+	"MYSQL_*", "POSTGRES_*", "REDIS_URL",
+	"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN",
+	"AWS_REGION", "AWS_DEFAULT_REGION", "AWS_PROFILE",
+	"GOOGLE_APPLICATION_CREDENTIALS",
+	"COMPOSE_*", "DOCKER_*",
+	"PYTHONPATH", "PYTHONUNBUFFERED", "PYTHONDONTWRITEBYTECODE",
+	"GOFLAGS", "GOPRIVATE", "CGO_ENABLED",
+	"NPM_CONFIG_*", "VITE_*",
+}
+
+// Unused reports variables that no scanned source file reads. Variables only
+// matched with confidence below minConfidence, or only set by a deployment
+// manifest, are reported as possibly unused since the scan can't be sure.
+// Variables matching an allow entry are never reported.
+func Unused(references map[string]Reference, minConfidence float64, allow []string) Rule {
+	return func(vars []env.Var, file string) []issues.Issue {
+		var out []issues.Issue
+		reported := make(map[string]bool)
+
+		for _, v := range vars {
+			if reported[v.Key] || MatchesAny(v.Key, allow) {
+				continue
+			}
+			reported[v.Key] = true
+
+			ref, found := references[v.Key]
+			switch {
+			case !found:
+				out = append(out, issues.NewIssue(
+					"unused variable",
+					v.Key,
+					file,
+					v.Line,
+					v.Line,
+					[]string{
+						"No source file reads this variable",
+						"Remove it, or add it to allow_unused if a tool outside the project reads it",
+					},
+				))
+			case ref.Confidence < minConfidence:
+				reason := fmt.Sprintf("Only low-confidence matches were found (%.0f%%)", ref.Confidence*100)
+				if ref.Confidence == 0 && ref.Declared {
+					reason = "Only deployment manifests set this variable; no source file reads it"
+				}
+				out = append(out, issues.NewIssue(
+					"possibly unused variable",
+					v.Key,
+					file,
+					v.Line,
+					v.Line,
+					[]string{
+						reason,
+						"Check with: ecolint scan --show-usages --min-confidence 0",
+					},
+				))
+			}
+		}
+
+		return out
+	}
+}
@@ -0,0 +1,75 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/tahcohcat/ecolint/domain/env"
+)
+
+func TestUnused(t *testing.T) {
+	references := map[string]Reference{
+		"DATABASE_URL": {Confidence: 1.0},
+		"LEGACY_FLAG":  {Confidence: 0.4},
+		"WORKER_COUNT": {Declared: true},
+		"REPLICAS":     {Confidence: 0.9, Declared: true},
+	}
+	rule := Unused(references, 0.7, append([]string{"SENTRY_*"}, DefaultUnusedAllow...))
+
+	tests := []struct {
+		name     string
+		vars     []env.Var
+		expected map[string]string // key -> issue name
+	}{
+		{
+			name: "all read",
+			vars: []env.Var{
+				{Key: "DATABASE_URL", Value: "postgres://localhost", Line: 1},
+				{Key: "REPLICAS", Value: "2", Line: 2},
+			},
+			expected: map[string]string{},
+		},
+		{
+			name: "never read",
+			vars: []env.Var{
+				{Key: "DATABASE_URL", Value: "postgres://localhost", Line: 1},
+				{Key: "OLD_API_URL", Value: "https://old.example.com", Line: 2},
+				{Key: "OLD_API_URL", Value: "https://older.example.com", Line: 3},
+			},
+			expected: map[string]string{"OLD_API_URL": "unused variable"},
+		},
+		{
+			name: "scan not sure",
+			vars: []env.Var{
+				{Key: "LEGACY_FLAG", Value: "1", Line: 1},
+				{Key: "WORKER_COUNT", Value: "4", Line: 2},
+			},
+			expected: map[string]string{
+				"LEGACY_FLAG":  "possibly unused variable",
+				"WORKER_COUNT": "possibly unused variable",
+			},
+		},
+		{
+			name: "allowlisted",
+			vars: []env.Var{
+				{Key: "NODE_ENV", Value: "development", Line: 1},
+				{Key: "PGHOST", Value: "localhost", Line: 2},
+				{Key: "SENTRY_DSN", Value: "https://sentry.example.com/1", Line: 3},
+			},
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rule(tt.vars, ".env")
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected %d issues, got %d: %v", len(tt.expected), len(got), got)
+			}
+			for _, issue := range got {
+				if tt.expected[issue.Key] != issue.Name {
+					t.Errorf("unexpected issue %q for %s", issue.Name, issue.Key)
+				}
+			}
+		})
+	}
+}